@@ -145,12 +145,12 @@ func (s *CollectionService) Delete(ctx context.Context, id string) (*Response, e
 	return s.client.Do(ctx, req, nil)
 }
 
-// AddPost adds a post (via its full ID) to a collection (via its id).
+// AddPost adds a post (via its full ID or ID36) to a collection (via its id).
 func (s *CollectionService) AddPost(ctx context.Context, postID, collectionID string) (*Response, error) {
 	path := "api/v1/collections/add_post_to_collection"
 
 	form := url.Values{}
-	form.Set("link_fullname", postID)
+	form.Set("link_fullname", toFullname(kindPost, postID))
 	form.Set("collection_id", collectionID)
 
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
@@ -161,12 +161,12 @@ func (s *CollectionService) AddPost(ctx context.Context, postID, collectionID st
 	return s.client.Do(ctx, req, nil)
 }
 
-// RemovePost removes a post (via its full ID) from a collection (via its id).
+// RemovePost removes a post (via its full ID or ID36) from a collection (via its id).
 func (s *CollectionService) RemovePost(ctx context.Context, postID, collectionID string) (*Response, error) {
 	path := "api/v1/collections/remove_post_in_collection"
 
 	form := url.Values{}
-	form.Set("link_fullname", postID)
+	form.Set("link_fullname", toFullname(kindPost, postID))
 	form.Set("collection_id", collectionID)
 
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
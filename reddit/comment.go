@@ -49,7 +49,7 @@ func (s *CommentService) Edit(ctx context.Context, id string, text string) (*Com
 	form := url.Values{}
 	form.Set("api_type", "json")
 	form.Set("return_rtjson", "true")
-	form.Set("thing_id", id)
+	form.Set("thing_id", toFullname(kindComment, id))
 	form.Set("text", text)
 
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
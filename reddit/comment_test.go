@@ -108,6 +108,10 @@ func TestCommentService_Delete(t *testing.T) {
 	resp, err := client.Comment.Delete(ctx, "t1_test")
 	require.NoError(t, err)
 	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = client.Comment.Delete(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
 }
 
 func TestCommentService_Save(t *testing.T) {
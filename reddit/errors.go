@@ -2,12 +2,48 @@ package reddit
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
 
+// Sentinel errors that the errors returned by the client can be compared against with errors.Is.
+// For example, errors.Is(err, ErrPrivateSubreddit) reports whether a request failed because
+// the subreddit is private. An error may match more than one of them: a private subreddit
+// is also an ErrForbidden.
+var (
+	// ErrNotFound is matched by errors caused by something that doesn't exist,
+	// e.g. a 404 Not Found response or a SUBREDDIT_NOEXIST error.
+	ErrNotFound = errors.New("reddit: not found")
+	// ErrForbidden is matched by errors caused by a lack of permission,
+	// e.g. a 403 Forbidden response or a SUBREDDIT_NOTALLOWED error.
+	ErrForbidden = errors.New("reddit: forbidden")
+	// ErrPrivateSubreddit is matched by errors caused by requesting a private subreddit.
+	ErrPrivateSubreddit = errors.New("reddit: private subreddit")
+	// ErrBanned is matched by errors caused by requesting a banned subreddit.
+	ErrBanned = errors.New("reddit: banned")
+	// ErrQuarantined is matched by errors caused by requesting a quarantined subreddit
+	// without having opted in to view its content.
+	ErrQuarantined = errors.New("reddit: quarantined")
+	// ErrRateLimited is matched by a *RateLimitError, a *SubmitRateLimitError, or an *ErrorResponse
+	// with the 429 status code.
+	ErrRateLimited = errors.New("reddit: rate limited")
+)
+
+// apiErrorLabels maps the labels of API errors to the sentinel errors they match.
+var apiErrorLabels = map[string]error{
+	"SUBREDDIT_NOEXIST":    ErrNotFound,
+	"USER_DOESNT_EXIST":    ErrNotFound,
+	"NO_THING_ID":          ErrNotFound,
+	"SUBREDDIT_NOTALLOWED": ErrForbidden,
+	"NOT_AUTHOR":           ErrForbidden,
+	"RATELIMIT":            ErrRateLimited,
+}
+
 // APIError is an error coming from Reddit.
 type APIError struct {
 	Label  string
@@ -35,6 +71,11 @@ func (e *APIError) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Is reports whether the error matches the target sentinel error, based on its label.
+func (e *APIError) Is(target error) bool {
+	return apiErrorLabels[e.Label] == target
+}
+
 // JSONErrorResponse is an error response that sometimes gets returned with a 200 code.
 type JSONErrorResponse struct {
 	// HTTP response that caused this error.
@@ -57,6 +98,58 @@ func (r *JSONErrorResponse) Error() string {
 	)
 }
 
+// Is reports whether any of the API errors matches the target sentinel error.
+func (r *JSONErrorResponse) Is(target error) bool {
+	for i := range r.JSON.Errors {
+		if r.JSON.Errors[i].Is(target) {
+			return true
+		}
+	}
+	return false
+}
+
+// SubmitRateLimitError occurs when Reddit rejects an action, such as submitting a post or a comment,
+// because the account has done it too many times recently (the RATELIMIT error).
+// Unlike a *RateLimitError, it's unrelated to the number of requests the client makes.
+type SubmitRateLimitError struct {
+	*JSONErrorResponse
+	// How long to wait before trying again, according to Reddit's message.
+	// It's zero if the message couldn't be parsed.
+	RetryAfter time.Duration
+}
+
+// Unwrap returns the underlying JSON error response.
+func (e *SubmitRateLimitError) Unwrap() error {
+	return e.JSONErrorResponse
+}
+
+var submitRateLimitRegex = regexp.MustCompile(`(\d+) (millisecond|second|minute|hour)s?`)
+
+// newSubmitRateLimitError returns a *SubmitRateLimitError if the JSON error response contains a RATELIMIT error.
+func newSubmitRateLimitError(r *JSONErrorResponse) (*SubmitRateLimitError, bool) {
+	for _, apiErr := range r.JSON.Errors {
+		if apiErr.Label != "RATELIMIT" {
+			continue
+		}
+
+		// e.g. "you are doing that too much. try again in 5 minutes."
+		// or "Looks like you've been doing that a lot. Take a break for 9 seconds before trying again."
+		err := &SubmitRateLimitError{JSONErrorResponse: r}
+		if m := submitRateLimitRegex.FindStringSubmatch(apiErr.Reason); m != nil {
+			n, _ := strconv.Atoi(m[1])
+			unit := map[string]time.Duration{
+				"millisecond": time.Millisecond,
+				"second":      time.Second,
+				"minute":      time.Minute,
+				"hour":        time.Hour,
+			}[m[2]]
+			err.RetryAfter = time.Duration(n) * unit
+		}
+		return err, true
+	}
+	return nil, false
+}
+
 // An ErrorResponse reports the error caused by an API request
 type ErrorResponse struct {
 	// HTTP response that caused this error
@@ -64,6 +157,8 @@ type ErrorResponse struct {
 
 	// Error message
 	Message string `json:"message"`
+	// Reason for the error, if any, e.g. "private", "banned" or "quarantined" for subreddits.
+	Reason string `json:"reason"`
 }
 
 func (r *ErrorResponse) Error() string {
@@ -73,6 +168,56 @@ func (r *ErrorResponse) Error() string {
 	)
 }
 
+// Is reports whether the error matches the target sentinel error, based on its status code and reason.
+func (r *ErrorResponse) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return r.Response.StatusCode == http.StatusNotFound
+	case ErrForbidden:
+		return r.Response.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return r.Response.StatusCode == http.StatusTooManyRequests
+	case ErrPrivateSubreddit:
+		return r.Reason == "private"
+	case ErrBanned:
+		return r.Reason == "banned"
+	case ErrQuarantined:
+		return r.Reason == "quarantined"
+	}
+	return false
+}
+
+// InsufficientScopeError occurs when the client's access token was not
+// granted the OAuth2 scope required by the endpoint it made a request to.
+type InsufficientScopeError struct {
+	// HTTP response that caused this error
+	Response *http.Response
+	// The scope required by the endpoint. It's empty if unknown.
+	Scope string
+	// The scopes granted to the client's access token, if known.
+	Granted []string
+}
+
+func (e *InsufficientScopeError) Error() string {
+	message := "insufficient scope"
+	if e.Scope != "" {
+		message += fmt.Sprintf(": the %q scope is required", e.Scope)
+	}
+	if e.Granted != nil {
+		message += fmt.Sprintf(" (granted scopes: %s)", strings.Join(e.Granted, ", "))
+	}
+
+	return fmt.Sprintf(
+		"%s %s: %d %s",
+		e.Response.Request.Method, e.Response.Request.URL, e.Response.StatusCode, message,
+	)
+}
+
+// Is reports whether the target is ErrForbidden.
+func (e *InsufficientScopeError) Is(target error) bool {
+	return target == ErrForbidden
+}
+
 // RateLimitError occurs when the client is sending too many requests to Reddit in a given time frame.
 type RateLimitError struct {
 	// Rate specifies the last known rate limit for the client
@@ -90,6 +235,11 @@ func (e *RateLimitError) Error() string {
 	)
 }
 
+// Is reports whether the target is ErrRateLimited.
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
 func (e *RateLimitError) formateRateReset() string {
 	d := time.Until(e.Rate.Reset).Round(time.Second)
 
@@ -0,0 +1,154 @@
+package reddit
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorResponse_Is(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/r/notfound/about", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "Not Found", "error": 404}`)
+	})
+
+	mux.HandleFunc("/r/banned/about", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"reason": "banned", "message": "Not Found", "error": 404}`)
+	})
+
+	mux.HandleFunc("/r/private/about", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"reason": "private", "message": "Forbidden", "error": 403}`)
+	})
+
+	mux.HandleFunc("/r/quarantined/about", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"reason": "quarantined", "quarantine_message": "", "message": "Forbidden", "error": 403}`)
+	})
+
+	mux.HandleFunc("/r/toomany/about", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"message": "Too Many Requests", "error": 429}`)
+	})
+
+	sentinels := []error{ErrNotFound, ErrForbidden, ErrPrivateSubreddit, ErrBanned, ErrQuarantined, ErrRateLimited}
+
+	tests := []struct {
+		subreddit string
+		reason    string
+		matches   []error
+	}{
+		{"notfound", "", []error{ErrNotFound}},
+		{"banned", "banned", []error{ErrNotFound, ErrBanned}},
+		{"private", "private", []error{ErrForbidden, ErrPrivateSubreddit}},
+		{"quarantined", "quarantined", []error{ErrForbidden, ErrQuarantined}},
+		{"toomany", "", []error{ErrRateLimited}},
+	}
+
+	for _, test := range tests {
+		_, _, err := client.Subreddit.Get(ctx, test.subreddit)
+		require.IsType(t, &ErrorResponse{}, err)
+		require.Equal(t, test.reason, err.(*ErrorResponse).Reason)
+
+		for _, sentinel := range sentinels {
+			require.Equal(t, containsError(test.matches, sentinel), errors.Is(err, sentinel), "%s: %v", test.subreddit, sentinel)
+		}
+	}
+}
+
+func TestJSONErrorResponse_Is(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/api/submit", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"json": {
+				"errors": [
+					["BAD_SR_NAME", "that name isn't going to work", "sr"],
+					["SUBREDDIT_NOEXIST", "that subreddit doesn't exist", "sr"]
+				]
+			}
+		}`)
+	})
+
+	_, _, err := client.Post.SubmitText(ctx, SubmitTextRequest{Subreddit: "test", Title: "test"})
+	require.IsType(t, &JSONErrorResponse{}, err)
+	require.True(t, errors.Is(err, ErrNotFound))
+	require.False(t, errors.Is(err, ErrForbidden))
+	require.False(t, errors.Is(err, ErrRateLimited))
+}
+
+func TestSubmitRateLimitError(t *testing.T) {
+	client, mux := setup(t)
+
+	var message string
+	mux.HandleFunc("/api/submit", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{
+			"json": {
+				"errors": [
+					["RATELIMIT", %q, "ratelimit"]
+				]
+			}
+		}`, message)
+	})
+
+	tests := []struct {
+		message    string
+		retryAfter time.Duration
+	}{
+		{"you are doing that too much. try again in 5 minutes.", 5 * time.Minute},
+		{"you are doing that too much. try again in 1 minute.", time.Minute},
+		{"Looks like you've been doing that a lot. Take a break for 9 seconds before trying again.", 9 * time.Second},
+		{"you are doing that too much. try again in 2 hours.", 2 * time.Hour},
+		{"you are doing that too much.", 0},
+	}
+
+	for _, test := range tests {
+		message = test.message
+
+		_, _, err := client.Post.SubmitText(ctx, SubmitTextRequest{Subreddit: "test", Title: "test"})
+		require.IsType(t, &SubmitRateLimitError{}, err)
+		require.Equal(t, test.retryAfter, err.(*SubmitRateLimitError).RetryAfter, test.message)
+		require.EqualError(t, err, fmt.Sprintf(`POST %s/api/submit: 200 field "ratelimit" caused RATELIMIT: %s`, client.BaseURL, test.message))
+		require.True(t, errors.Is(err, ErrRateLimited))
+
+		var jsonErr *JSONErrorResponse
+		require.True(t, errors.As(err, &jsonErr))
+		require.Equal(t, "RATELIMIT", jsonErr.JSON.Errors[0].Label)
+	}
+}
+
+func TestRateLimitError_Is(t *testing.T) {
+	client, _ := setup(t)
+
+	client.rate.Remaining = 0
+	client.rate.Reset = time.Now().Add(time.Minute)
+
+	req, err := client.NewRequest(http.MethodGet, "api/v1/test", nil)
+	require.NoError(t, err)
+
+	_, err = client.Do(ctx, req, nil)
+	require.True(t, errors.Is(err, ErrRateLimited))
+	require.False(t, errors.Is(err, ErrForbidden))
+}
+
+func TestInsufficientScopeError_Is(t *testing.T) {
+	err := &InsufficientScopeError{Scope: "read"}
+	require.True(t, errors.Is(err, ErrForbidden))
+	require.False(t, errors.Is(err, ErrNotFound))
+}
+
+func containsError(errs []error, target error) bool {
+	for _, err := range errs {
+		if err == target {
+			return true
+		}
+	}
+	return false
+}
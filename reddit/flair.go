@@ -414,7 +414,7 @@ func (s *FlairService) ChoicesOf(ctx context.Context, subreddit, username string
 func (s *FlairService) ChoicesForPost(ctx context.Context, postID string) ([]*FlairChoice, *FlairChoice, *Response, error) {
 	path := "api/flairselector"
 	form := url.Values{}
-	form.Set("link", postID)
+	form.Set("link", toFullname(kindPost, postID))
 	return s.choices(ctx, path, form)
 }
 
@@ -490,7 +490,7 @@ func (s *FlairService) SelectForPost(ctx context.Context, postID string, request
 		return nil, err
 	}
 	form.Set("api_type", "json")
-	form.Set("link", postID)
+	form.Set("link", toFullname(kindPost, postID))
 
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
 	if err != nil {
@@ -507,7 +507,7 @@ func (s *FlairService) RemoveFromPost(ctx context.Context, postID string) (*Resp
 
 	form := url.Values{}
 	form.Set("api_type", "json")
-	form.Set("link", postID)
+	form.Set("link", toFullname(kindPost, postID))
 
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
 	if err != nil {
@@ -0,0 +1,147 @@
+package reddit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxID36Length is the length of the largest uint64 in base 36.
+const maxID36Length = 13
+
+// Fullname is the full ID of a thing on Reddit, made of its kind and its ID36, e.g. t3_abc123 for a post.
+// The kinds are:
+//
+//	t1: comment
+//	t2: account
+//	t3: post
+//	t4: message
+//	t5: subreddit
+//	t6: award
+type Fullname string
+
+// NewFullname returns the fullname of the thing of the kind (e.g. t3) with the ID36.
+func NewFullname(kind, id36 string) Fullname {
+	return Fullname(kind + "_" + id36)
+}
+
+// ParseFullname parses and validates a fullname, e.g. t3_abc123.
+func ParseFullname(s string) (Fullname, error) {
+	f := Fullname(s)
+	if err := f.Validate(); err != nil {
+		return "", err
+	}
+	return f, nil
+}
+
+// Kind returns the kind of the thing, e.g. t3 for a post.
+// It's empty if the fullname has no kind.
+func (f Fullname) Kind() string {
+	i := strings.IndexByte(string(f), '_')
+	if i < 0 {
+		return ""
+	}
+	return string(f[:i])
+}
+
+// ID36 returns the base 36 ID of the thing, e.g. abc123 for t3_abc123.
+func (f Fullname) ID36() string {
+	return string(f[strings.IndexByte(string(f), '_')+1:])
+}
+
+// ID returns the base 36 ID of the thing as a number.
+func (f Fullname) ID() (uint64, error) {
+	return ParseID36(f.ID36())
+}
+
+func (f Fullname) String() string {
+	return string(f)
+}
+
+// Validate returns an error if the fullname isn't made of a kind from t1 to t6 and a valid ID36.
+func (f Fullname) Validate() error {
+	i := strings.IndexByte(string(f), '_')
+	if i < 0 {
+		return fmt.Errorf("invalid fullname %q: missing kind", string(f))
+	}
+	if kind := f.Kind(); !isFullnameKind(kind) {
+		return fmt.Errorf("invalid fullname %q: unknown kind %q", string(f), kind)
+	}
+	if err := validateID36(f.ID36()); err != nil {
+		return fmt.Errorf("invalid fullname %q: %w", string(f), err)
+	}
+	return nil
+}
+
+func isFullnameKind(kind string) bool {
+	switch kind {
+	case kindComment, kindUser, kindPost, kindMessage, kindSubreddit, kindTrophy:
+		return true
+	}
+	return false
+}
+
+// ParseID36 converts a base 36 ID, e.g. abc123, to a number.
+func ParseID36(id36 string) (uint64, error) {
+	if err := validateID36(id36); err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(id36, 36, 64)
+}
+
+// FormatID36 converts a number to a base 36 ID.
+func FormatID36(id uint64) string {
+	return strconv.FormatUint(id, 36)
+}
+
+func validateID36(id36 string) error {
+	if id36 == "" {
+		return fmt.Errorf("invalid ID36 %q: empty", id36)
+	}
+	if len(id36) > maxID36Length {
+		return fmt.Errorf("invalid ID36 %q: too long", id36)
+	}
+	for _, r := range id36 {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'z') {
+			return fmt.Errorf("invalid ID36 %q: unexpected character %q", id36, r)
+		}
+	}
+	return nil
+}
+
+// toID36 returns the ID36 of the thing with the ID, which can either be its fullname or its ID36.
+func toID36(id string) string {
+	if f := Fullname(id); f.Validate() == nil {
+		return f.ID36()
+	}
+	return id
+}
+
+// toFullname returns the fullname of the thing of the kind with the ID,
+// which can either be its fullname or its ID36.
+func toFullname(kind, id string) string {
+	if validateID36(id) == nil {
+		return string(NewFullname(kind, id))
+	}
+	return id
+}
+
+// toFullnames is like toFullname, for multiple IDs.
+func toFullnames(kind string, ids []string) []string {
+	fullnames := make([]string, len(ids))
+	for i, id := range ids {
+		fullnames[i] = toFullname(kind, id)
+	}
+	return fullnames
+}
+
+// validateFullnames returns an error if one of the IDs isn't a fullname. Methods accepting things
+// of different kinds use it, since the kind of a thing can't be told from its ID36.
+func validateFullnames(ids ...string) error {
+	for _, id := range ids {
+		if err := Fullname(id).Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
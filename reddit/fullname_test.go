@@ -0,0 +1,65 @@
+package reddit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFullname(t *testing.T) {
+	f, err := ParseFullname("t3_abc123")
+	require.NoError(t, err)
+	require.Equal(t, Fullname("t3_abc123"), f)
+	require.Equal(t, "t3", f.Kind())
+	require.Equal(t, "abc123", f.ID36())
+	require.Equal(t, "t3_abc123", f.String())
+
+	id, err := f.ID()
+	require.NoError(t, err)
+	require.Equal(t, uint64(623698779), id)
+
+	for s, msg := range map[string]string{
+		"abc123":    `invalid fullname "abc123": missing kind`,
+		"t9_abc123": `invalid fullname "t9_abc123": unknown kind "t9"`,
+		"t3_":       `invalid fullname "t3_": invalid ID36 "": empty`,
+		"t3_ABC123": `invalid fullname "t3_ABC123": invalid ID36 "ABC123": unexpected character 'A'`,
+		"t3_a_b":    `invalid fullname "t3_a_b": invalid ID36 "a_b": unexpected character '_'`,
+	} {
+		_, err := ParseFullname(s)
+		require.EqualError(t, err, msg)
+	}
+}
+
+func TestNewFullname(t *testing.T) {
+	f := NewFullname(kindUser, "abc123")
+	require.Equal(t, Fullname("t2_abc123"), f)
+	require.NoError(t, f.Validate())
+}
+
+func TestID36(t *testing.T) {
+	id, err := ParseID36("abc123")
+	require.NoError(t, err)
+	require.Equal(t, uint64(623698779), id)
+	require.Equal(t, "abc123", FormatID36(id))
+
+	max := FormatID36(math.MaxUint64)
+	require.Equal(t, "3w5e11264sgsf", max)
+	id, err = ParseID36(max)
+	require.NoError(t, err)
+	require.Equal(t, uint64(math.MaxUint64), id)
+
+	_, err = ParseID36("3w5e11264sgsg")
+	require.Error(t, err)
+
+	_, err = ParseID36("3w5e11264sgsf0")
+	require.EqualError(t, err, `invalid ID36 "3w5e11264sgsf0": too long`)
+}
+
+func TestToFullname(t *testing.T) {
+	require.Equal(t, "t3_abc123", toFullname(kindPost, "abc123"))
+	require.Equal(t, "t3_abc123", toFullname(kindPost, "t3_abc123"))
+	require.Equal(t, "abc123", toID36("abc123"))
+	require.Equal(t, "abc123", toID36("t3_abc123"))
+	require.Equal(t, []string{"t5_a", "t5_b"}, toFullnames(kindSubreddit, []string{"a", "t5_b"}))
+}
@@ -0,0 +1,548 @@
+package reddit
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrIteratorDone is returned by an iterator's Next method when there are no more items to return.
+var ErrIteratorDone = errors.New("no more items in iterator")
+
+const (
+	// Reddit stops listings at about this many items, no matter how many more there are.
+	listingCeiling = 1000
+	// Listings that reach the ceiling can come up a bit short of it, e.g. due to deleted items.
+	listingCeilingMargin = 50
+	// Maximum number of items per page for most listings.
+	defaultPageSize = 100
+)
+
+// IteratorOptions configures an iterator.
+type IteratorOptions struct {
+	// Number of items to request per page. Defaults to 100, which is the max for most listings.
+	PageSize int
+	// Maximum number of items the iterator will return. If 0, there is no limit.
+	MaxItems int
+	// If set, the iterator starts after the item with this full ID.
+	After string
+}
+
+// pager holds the pagination state shared by all the iterators.
+// It follows the after anchor of each page until the listing is exhausted.
+type pager struct {
+	pageSize int
+	maxItems int
+	after    string
+
+	fetched    int
+	returned   int
+	exhausted  bool
+	hitCeiling bool
+	response   *Response
+}
+
+func newPager(opts *IteratorOptions) pager {
+	p := pager{pageSize: defaultPageSize}
+	if opts != nil {
+		if opts.PageSize > 0 {
+			p.pageSize = opts.PageSize
+		}
+		p.maxItems = opts.MaxItems
+		p.after = opts.After
+	}
+	return p
+}
+
+// maxReached reports whether the iterator returned the max number of items.
+func (p *pager) maxReached() bool {
+	return p.maxItems > 0 && p.returned >= p.maxItems
+}
+
+// fetch gets the next page using list, which returns the number of items in the page.
+func (p *pager) fetch(ctx context.Context, list func(context.Context, *ListOptions) (int, *Response, error)) error {
+	limit := p.pageSize
+	if p.maxItems > 0 && p.maxItems-p.returned < limit {
+		limit = p.maxItems - p.returned
+	}
+
+	n, resp, err := list(ctx, &ListOptions{Limit: limit, After: p.after, Count: p.fetched})
+	if err != nil {
+		return err
+	}
+
+	p.response = resp
+	p.fetched += n
+
+	var after string
+	if resp != nil {
+		after = resp.After
+	}
+
+	// an anchor that doesn't move would make the iterator loop forever
+	if after == "" || after == p.after || n == 0 {
+		p.exhausted = true
+		p.hitCeiling = p.fetched >= listingCeiling-listingCeilingMargin
+	}
+	p.after = after
+
+	return nil
+}
+
+// next gets the next item ready at the front of the iterator's buffer, whose length is returned by buffered,
+// fetching pages with list as needed. list fills the buffer with the items of a page and returns their number.
+// It returns ErrIteratorDone when there are no more items.
+func (p *pager) next(ctx context.Context, buffered func() int, list func(context.Context, *ListOptions) (int, *Response, error)) error {
+	if p.maxReached() {
+		return ErrIteratorDone
+	}
+
+	for buffered() == 0 {
+		if p.exhausted {
+			return ErrIteratorDone
+		}
+		if err := p.fetch(ctx, list); err != nil {
+			return err
+		}
+	}
+
+	p.returned++
+	return nil
+}
+
+// all calls next, which appends the next item to the iterator's result, until there are no more items.
+func (p *pager) all(next func() error) error {
+	for {
+		err := next()
+		if err == ErrIteratorDone {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// HitCeiling reports whether the listing ended because it reached the limit of about 1000 items that
+// Reddit imposes on listings. If true, older items exist but cannot be reached through this listing;
+// try narrowing it down instead, e.g. with a search or a different sort.
+//
+// It's an estimate: since pages can come up short, e.g. due to deleted items, a listing that simply
+// ends between 950 and 1000 items also reports true.
+func (p *pager) HitCeiling() bool {
+	return p.hitCeiling
+}
+
+// Response returns the response of the latest page that was fetched, or nil if none was.
+func (p *pager) Response() *Response {
+	return p.response
+}
+
+// PostIterator iterates over posts, fetching the pages of a listing as needed.
+type PostIterator struct {
+	pager
+	list  func(context.Context, *ListOptions) ([]*Post, *Response, error)
+	items []*Post
+}
+
+// NewPostIterator returns an iterator over the posts returned by list, which should get one page of the listing
+// using the *ListOptions it is given, e.g. by passing them to one of the client's methods. opts can be nil.
+func NewPostIterator(list func(context.Context, *ListOptions) ([]*Post, *Response, error), opts *IteratorOptions) *PostIterator {
+	return &PostIterator{pager: newPager(opts), list: list}
+}
+
+// Next returns the next item. It returns ErrIteratorDone when there are no more items.
+func (it *PostIterator) Next(ctx context.Context) (*Post, error) {
+	err := it.next(ctx, func() int { return len(it.items) }, func(ctx context.Context, opts *ListOptions) (n int, resp *Response, err error) {
+		it.items, resp, err = it.list(ctx, opts)
+		return len(it.items), resp, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	item := it.items[0]
+	it.items = it.items[1:]
+	return item, nil
+}
+
+// All returns all the remaining items. If an error occurs, the items returned until then are returned with it.
+func (it *PostIterator) All(ctx context.Context) ([]*Post, error) {
+	var items []*Post
+	err := it.all(func() error {
+		item, err := it.Next(ctx)
+		if err == nil {
+			items = append(items, item)
+		}
+		return err
+	})
+	return items, err
+}
+
+// CommentIterator iterates over comments, fetching the pages of a listing as needed.
+type CommentIterator struct {
+	pager
+	list  func(context.Context, *ListOptions) ([]*Comment, *Response, error)
+	items []*Comment
+}
+
+// NewCommentIterator returns an iterator over the comments returned by list, which should get one page of the listing
+// using the *ListOptions it is given, e.g. by passing them to one of the client's methods. opts can be nil.
+func NewCommentIterator(list func(context.Context, *ListOptions) ([]*Comment, *Response, error), opts *IteratorOptions) *CommentIterator {
+	return &CommentIterator{pager: newPager(opts), list: list}
+}
+
+// Next returns the next item. It returns ErrIteratorDone when there are no more items.
+func (it *CommentIterator) Next(ctx context.Context) (*Comment, error) {
+	err := it.next(ctx, func() int { return len(it.items) }, func(ctx context.Context, opts *ListOptions) (n int, resp *Response, err error) {
+		it.items, resp, err = it.list(ctx, opts)
+		return len(it.items), resp, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	item := it.items[0]
+	it.items = it.items[1:]
+	return item, nil
+}
+
+// All returns all the remaining items. If an error occurs, the items returned until then are returned with it.
+func (it *CommentIterator) All(ctx context.Context) ([]*Comment, error) {
+	var items []*Comment
+	err := it.all(func() error {
+		item, err := it.Next(ctx)
+		if err == nil {
+			items = append(items, item)
+		}
+		return err
+	})
+	return items, err
+}
+
+// SubredditIterator iterates over subreddits, fetching the pages of a listing as needed.
+type SubredditIterator struct {
+	pager
+	list  func(context.Context, *ListOptions) ([]*Subreddit, *Response, error)
+	items []*Subreddit
+}
+
+// NewSubredditIterator returns an iterator over the subreddits returned by list, which should get one page of the listing
+// using the *ListOptions it is given, e.g. by passing them to one of the client's methods. opts can be nil.
+func NewSubredditIterator(list func(context.Context, *ListOptions) ([]*Subreddit, *Response, error), opts *IteratorOptions) *SubredditIterator {
+	return &SubredditIterator{pager: newPager(opts), list: list}
+}
+
+// Next returns the next item. It returns ErrIteratorDone when there are no more items.
+func (it *SubredditIterator) Next(ctx context.Context) (*Subreddit, error) {
+	err := it.next(ctx, func() int { return len(it.items) }, func(ctx context.Context, opts *ListOptions) (n int, resp *Response, err error) {
+		it.items, resp, err = it.list(ctx, opts)
+		return len(it.items), resp, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	item := it.items[0]
+	it.items = it.items[1:]
+	return item, nil
+}
+
+// All returns all the remaining items. If an error occurs, the items returned until then are returned with it.
+func (it *SubredditIterator) All(ctx context.Context) ([]*Subreddit, error) {
+	var items []*Subreddit
+	err := it.all(func() error {
+		item, err := it.Next(ctx)
+		if err == nil {
+			items = append(items, item)
+		}
+		return err
+	})
+	return items, err
+}
+
+// UserIterator iterates over users, fetching the pages of a listing as needed.
+type UserIterator struct {
+	pager
+	list  func(context.Context, *ListOptions) ([]*User, *Response, error)
+	items []*User
+}
+
+// NewUserIterator returns an iterator over the users returned by list, which should get one page of the listing
+// using the *ListOptions it is given, e.g. by passing them to one of the client's methods. opts can be nil.
+func NewUserIterator(list func(context.Context, *ListOptions) ([]*User, *Response, error), opts *IteratorOptions) *UserIterator {
+	return &UserIterator{pager: newPager(opts), list: list}
+}
+
+// Next returns the next item. It returns ErrIteratorDone when there are no more items.
+func (it *UserIterator) Next(ctx context.Context) (*User, error) {
+	err := it.next(ctx, func() int { return len(it.items) }, func(ctx context.Context, opts *ListOptions) (n int, resp *Response, err error) {
+		it.items, resp, err = it.list(ctx, opts)
+		return len(it.items), resp, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	item := it.items[0]
+	it.items = it.items[1:]
+	return item, nil
+}
+
+// All returns all the remaining items. If an error occurs, the items returned until then are returned with it.
+func (it *UserIterator) All(ctx context.Context) ([]*User, error) {
+	var items []*User
+	err := it.all(func() error {
+		item, err := it.Next(ctx)
+		if err == nil {
+			items = append(items, item)
+		}
+		return err
+	})
+	return items, err
+}
+
+// MessageIterator iterates over messages, fetching the pages of a listing as needed.
+type MessageIterator struct {
+	pager
+	list  func(context.Context, *ListOptions) ([]*Message, *Response, error)
+	items []*Message
+}
+
+// NewMessageIterator returns an iterator over the messages returned by list, which should get one page of the listing
+// using the *ListOptions it is given, e.g. by passing them to one of the client's methods. opts can be nil.
+func NewMessageIterator(list func(context.Context, *ListOptions) ([]*Message, *Response, error), opts *IteratorOptions) *MessageIterator {
+	return &MessageIterator{pager: newPager(opts), list: list}
+}
+
+// Next returns the next item. It returns ErrIteratorDone when there are no more items.
+func (it *MessageIterator) Next(ctx context.Context) (*Message, error) {
+	err := it.next(ctx, func() int { return len(it.items) }, func(ctx context.Context, opts *ListOptions) (n int, resp *Response, err error) {
+		it.items, resp, err = it.list(ctx, opts)
+		return len(it.items), resp, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	item := it.items[0]
+	it.items = it.items[1:]
+	return item, nil
+}
+
+// All returns all the remaining items. If an error occurs, the items returned until then are returned with it.
+func (it *MessageIterator) All(ctx context.Context) ([]*Message, error) {
+	var items []*Message
+	err := it.all(func() error {
+		item, err := it.Next(ctx)
+		if err == nil {
+			items = append(items, item)
+		}
+		return err
+	})
+	return items, err
+}
+
+// ModActionIterator iterates over moderation actions, fetching the pages of a listing as needed.
+type ModActionIterator struct {
+	pager
+	list  func(context.Context, *ListOptions) ([]*ModAction, *Response, error)
+	items []*ModAction
+}
+
+// NewModActionIterator returns an iterator over the moderation actions returned by list, which should get one page of the listing
+// using the *ListOptions it is given, e.g. by passing them to one of the client's methods. opts can be nil.
+func NewModActionIterator(list func(context.Context, *ListOptions) ([]*ModAction, *Response, error), opts *IteratorOptions) *ModActionIterator {
+	return &ModActionIterator{pager: newPager(opts), list: list}
+}
+
+// Next returns the next item. It returns ErrIteratorDone when there are no more items.
+func (it *ModActionIterator) Next(ctx context.Context) (*ModAction, error) {
+	err := it.next(ctx, func() int { return len(it.items) }, func(ctx context.Context, opts *ListOptions) (n int, resp *Response, err error) {
+		it.items, resp, err = it.list(ctx, opts)
+		return len(it.items), resp, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	item := it.items[0]
+	it.items = it.items[1:]
+	return item, nil
+}
+
+// All returns all the remaining items. If an error occurs, the items returned until then are returned with it.
+func (it *ModActionIterator) All(ctx context.Context) ([]*ModAction, error) {
+	var items []*ModAction
+	err := it.all(func() error {
+		item, err := it.Next(ctx)
+		if err == nil {
+			items = append(items, item)
+		}
+		return err
+	})
+	return items, err
+}
+
+// WikiPageRevisionIterator iterates over wiki page revisions, fetching the pages of a listing as needed.
+type WikiPageRevisionIterator struct {
+	pager
+	list  func(context.Context, *ListOptions) ([]*WikiPageRevision, *Response, error)
+	items []*WikiPageRevision
+}
+
+// NewWikiPageRevisionIterator returns an iterator over the wiki page revisions returned by list, which should get one page of the listing
+// using the *ListOptions it is given, e.g. by passing them to one of the client's methods. opts can be nil.
+func NewWikiPageRevisionIterator(list func(context.Context, *ListOptions) ([]*WikiPageRevision, *Response, error), opts *IteratorOptions) *WikiPageRevisionIterator {
+	return &WikiPageRevisionIterator{pager: newPager(opts), list: list}
+}
+
+// Next returns the next item. It returns ErrIteratorDone when there are no more items.
+func (it *WikiPageRevisionIterator) Next(ctx context.Context) (*WikiPageRevision, error) {
+	err := it.next(ctx, func() int { return len(it.items) }, func(ctx context.Context, opts *ListOptions) (n int, resp *Response, err error) {
+		it.items, resp, err = it.list(ctx, opts)
+		return len(it.items), resp, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	item := it.items[0]
+	it.items = it.items[1:]
+	return item, nil
+}
+
+// All returns all the remaining items. If an error occurs, the items returned until then are returned with it.
+func (it *WikiPageRevisionIterator) All(ctx context.Context) ([]*WikiPageRevision, error) {
+	var items []*WikiPageRevision
+	err := it.all(func() error {
+		item, err := it.Next(ctx)
+		if err == nil {
+			items = append(items, item)
+		}
+		return err
+	})
+	return items, err
+}
+
+// RelationshipIterator iterates over relationships, fetching the pages of a listing as needed.
+type RelationshipIterator struct {
+	pager
+	list  func(context.Context, *ListOptions) ([]*Relationship, *Response, error)
+	items []*Relationship
+}
+
+// NewRelationshipIterator returns an iterator over the relationships returned by list, which should get one page of the listing
+// using the *ListOptions it is given, e.g. by passing them to one of the client's methods. opts can be nil.
+func NewRelationshipIterator(list func(context.Context, *ListOptions) ([]*Relationship, *Response, error), opts *IteratorOptions) *RelationshipIterator {
+	return &RelationshipIterator{pager: newPager(opts), list: list}
+}
+
+// Next returns the next item. It returns ErrIteratorDone when there are no more items.
+func (it *RelationshipIterator) Next(ctx context.Context) (*Relationship, error) {
+	err := it.next(ctx, func() int { return len(it.items) }, func(ctx context.Context, opts *ListOptions) (n int, resp *Response, err error) {
+		it.items, resp, err = it.list(ctx, opts)
+		return len(it.items), resp, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	item := it.items[0]
+	it.items = it.items[1:]
+	return item, nil
+}
+
+// All returns all the remaining items. If an error occurs, the items returned until then are returned with it.
+func (it *RelationshipIterator) All(ctx context.Context) ([]*Relationship, error) {
+	var items []*Relationship
+	err := it.all(func() error {
+		item, err := it.Next(ctx)
+		if err == nil {
+			items = append(items, item)
+		}
+		return err
+	})
+	return items, err
+}
+
+// BanIterator iterates over bans, fetching the pages of a listing as needed.
+type BanIterator struct {
+	pager
+	list  func(context.Context, *ListOptions) ([]*Ban, *Response, error)
+	items []*Ban
+}
+
+// NewBanIterator returns an iterator over the bans returned by list, which should get one page of the listing
+// using the *ListOptions it is given, e.g. by passing them to one of the client's methods. opts can be nil.
+func NewBanIterator(list func(context.Context, *ListOptions) ([]*Ban, *Response, error), opts *IteratorOptions) *BanIterator {
+	return &BanIterator{pager: newPager(opts), list: list}
+}
+
+// Next returns the next item. It returns ErrIteratorDone when there are no more items.
+func (it *BanIterator) Next(ctx context.Context) (*Ban, error) {
+	err := it.next(ctx, func() int { return len(it.items) }, func(ctx context.Context, opts *ListOptions) (n int, resp *Response, err error) {
+		it.items, resp, err = it.list(ctx, opts)
+		return len(it.items), resp, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	item := it.items[0]
+	it.items = it.items[1:]
+	return item, nil
+}
+
+// All returns all the remaining items. If an error occurs, the items returned until then are returned with it.
+func (it *BanIterator) All(ctx context.Context) ([]*Ban, error) {
+	var items []*Ban
+	err := it.all(func() error {
+		item, err := it.Next(ctx)
+		if err == nil {
+			items = append(items, item)
+		}
+		return err
+	})
+	return items, err
+}
+
+// LiveThreadUpdateIterator iterates over live thread updates, fetching the pages of a listing as needed.
+type LiveThreadUpdateIterator struct {
+	pager
+	list  func(context.Context, *ListOptions) ([]*LiveThreadUpdate, *Response, error)
+	items []*LiveThreadUpdate
+}
+
+// NewLiveThreadUpdateIterator returns an iterator over the live thread updates returned by list, which should get one page of the listing
+// using the *ListOptions it is given, e.g. by passing them to one of the client's methods. opts can be nil.
+func NewLiveThreadUpdateIterator(list func(context.Context, *ListOptions) ([]*LiveThreadUpdate, *Response, error), opts *IteratorOptions) *LiveThreadUpdateIterator {
+	return &LiveThreadUpdateIterator{pager: newPager(opts), list: list}
+}
+
+// Next returns the next item. It returns ErrIteratorDone when there are no more items.
+func (it *LiveThreadUpdateIterator) Next(ctx context.Context) (*LiveThreadUpdate, error) {
+	err := it.next(ctx, func() int { return len(it.items) }, func(ctx context.Context, opts *ListOptions) (n int, resp *Response, err error) {
+		it.items, resp, err = it.list(ctx, opts)
+		return len(it.items), resp, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	item := it.items[0]
+	it.items = it.items[1:]
+	return item, nil
+}
+
+// All returns all the remaining items. If an error occurs, the items returned until then are returned with it.
+func (it *LiveThreadUpdateIterator) All(ctx context.Context) ([]*LiveThreadUpdate, error) {
+	var items []*LiveThreadUpdate
+	err := it.all(func() error {
+		item, err := it.Next(ctx)
+		if err == nil {
+			items = append(items, item)
+		}
+		return err
+	})
+	return items, err
+}
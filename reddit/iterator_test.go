@@ -0,0 +1,150 @@
+package reddit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// listingPage returns a page of a post listing, with the posts t3_<from> to t3_<to>.
+func listingPage(from, to int, after string) string {
+	var children string
+	for i := from; i <= to; i++ {
+		if children != "" {
+			children += ","
+		}
+		children += fmt.Sprintf(`{"kind": "t3", "data": {"name": "t3_%d", "id": "%d"}}`, i, i)
+	}
+	return fmt.Sprintf(`{"kind": "Listing", "data": {"after": %q, "children": [%s]}}`, after, children)
+}
+
+func TestPostIterator(t *testing.T) {
+	client, mux := setup(t)
+
+	var afters []string
+	mux.HandleFunc("/r/golang/new", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "2", r.Form.Get("limit"))
+		afters = append(afters, r.Form.Get("after"))
+
+		switch r.Form.Get("after") {
+		case "":
+			fmt.Fprint(w, listingPage(1, 2, "t3_2"))
+		case "t3_2":
+			fmt.Fprint(w, listingPage(3, 4, "t3_4"))
+		case "t3_4":
+			fmt.Fprint(w, listingPage(5, 5, ""))
+		}
+	})
+
+	it := NewPostIterator(func(ctx context.Context, opts *ListOptions) ([]*Post, *Response, error) {
+		return client.Subreddit.NewPosts(ctx, "golang", opts)
+	}, &IteratorOptions{PageSize: 2})
+
+	post, err := it.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "t3_1", post.FullID)
+	require.NotNil(t, it.Response())
+
+	posts, err := it.All(ctx)
+	require.NoError(t, err)
+	require.Len(t, posts, 4)
+	require.Equal(t, "t3_5", posts[3].FullID)
+	require.False(t, it.HitCeiling())
+
+	_, err = it.Next(ctx)
+	require.Equal(t, ErrIteratorDone, err)
+	require.Equal(t, []string{"", "t3_2", "t3_4"}, afters)
+}
+
+func TestPostIterator_MaxItems(t *testing.T) {
+	var limits []int
+	it := NewPostIterator(func(ctx context.Context, opts *ListOptions) ([]*Post, *Response, error) {
+		limits = append(limits, opts.Limit)
+
+		posts := make([]*Post, opts.Limit)
+		for i := range posts {
+			posts[i] = &Post{FullID: "t3_" + strconv.Itoa(i)}
+		}
+		return posts, &Response{After: "t3_" + strconv.Itoa(len(limits))}, nil
+	}, &IteratorOptions{MaxItems: 250, After: "t3_0"})
+
+	posts, err := it.All(ctx)
+	require.NoError(t, err)
+	require.Len(t, posts, 250)
+	// the last page only requests the items needed to reach the max
+	require.Equal(t, []int{100, 100, 50}, limits)
+	require.False(t, it.HitCeiling())
+}
+
+func TestPostIterator_HitCeiling(t *testing.T) {
+	var pages int
+	it := NewPostIterator(func(ctx context.Context, opts *ListOptions) ([]*Post, *Response, error) {
+		pages++
+
+		posts := make([]*Post, opts.Limit)
+		for i := range posts {
+			posts[i] = &Post{}
+		}
+
+		// Reddit stops returning an anchor once the listing reaches about 1000 items
+		after := "t3_" + strconv.Itoa(pages)
+		if pages == 10 {
+			posts = posts[:97]
+			after = ""
+		}
+		return posts, &Response{After: after}, nil
+	}, nil)
+
+	posts, err := it.All(ctx)
+	require.NoError(t, err)
+	require.Len(t, posts, 997)
+	require.True(t, it.HitCeiling())
+}
+
+func TestPostIterator_Error(t *testing.T) {
+	var calls int
+	it := NewPostIterator(func(ctx context.Context, opts *ListOptions) ([]*Post, *Response, error) {
+		calls++
+		if calls == 2 {
+			return nil, nil, errors.New("test error")
+		}
+		return []*Post{{FullID: "t3_" + strconv.Itoa(calls)}}, &Response{After: "t3_" + strconv.Itoa(calls)}, nil
+	}, nil)
+
+	posts, err := it.All(ctx)
+	require.EqualError(t, err, "test error")
+	require.Len(t, posts, 1)
+
+	// the failed page is requested again
+	post, err := it.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "t3_3", post.FullID)
+}
+
+func TestMessageIterator(t *testing.T) {
+	client, mux := setup(t)
+
+	blob, err := readFileContents("../testdata/message/inbox.json")
+	require.NoError(t, err)
+
+	mux.HandleFunc("/message/inbox", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		fmt.Fprint(w, blob)
+	})
+
+	it := NewMessageIterator(func(ctx context.Context, opts *ListOptions) ([]*Message, *Response, error) {
+		comments, messages, resp, err := client.Message.Inbox(ctx, opts)
+		return append(comments, messages...), resp, err
+	}, nil)
+
+	messages, err := it.All(ctx)
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+}
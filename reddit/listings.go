@@ -3,7 +3,9 @@ package reddit
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
 )
 
 // ListingsService handles communication with the listing
@@ -14,27 +16,175 @@ type ListingsService struct {
 	client *Client
 }
 
+// maxListingIDs is the maximum number of full IDs Reddit accepts in a single request to api/info or by_id.
+const maxListingIDs = 100
+
 // Get posts, comments, and subreddits from their full IDs.
+// Reddit returns at most 100 things per request, so the IDs are requested 100 at a time
+// (see WithListingsConcurrency), and the results are sorted in the order of the IDs.
+// The IDs of the things that weren't returned, e.g. because they were deleted or are
+// inaccessible, are in the response's Missing field. The response is the one of the last request.
 func (s *ListingsService) Get(ctx context.Context, ids ...string) ([]*Post, []*Comment, []*Subreddit, *Response, error) {
-	path := "api/info"
-	params := struct {
-		IDs []string `url:"id,omitempty,comma"`
-	}{ids}
-
-	l, resp, err := s.client.getListing(ctx, path, params)
+	t, resp, err := s.getThings(ctx, ids, s.info)
 	if err != nil {
 		return nil, nil, nil, resp, err
 	}
-
-	return l.Posts(), l.Comments(), l.Subreddits(), resp, nil
+	return t.Posts, t.Comments, t.Subreddits, resp, nil
 }
 
-// GetPosts returns posts from their full IDs.
+// GetPosts returns posts from their full IDs or ID36s.
+// Like Get, it requests the IDs 100 at a time and reports the missing ones in the response.
 func (s *ListingsService) GetPosts(ctx context.Context, ids ...string) ([]*Post, *Response, error) {
-	path := fmt.Sprintf("by_id/%s", strings.Join(ids, ","))
-	l, resp, err := s.client.getListing(ctx, path, nil)
+	t, resp, err := s.getThings(ctx, toFullnames(kindPost, ids), func(ctx context.Context, ids []string) (*listing, *Response, error) {
+		path := fmt.Sprintf("by_id/%s", strings.Join(ids, ","))
+		return s.client.getListing(ctx, path, nil)
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+	return t.Posts, resp, nil
+}
+
+// GetComments returns comments from their full IDs or ID36s.
+// Like Get, it requests the IDs 100 at a time and reports the missing ones in the response.
+func (s *ListingsService) GetComments(ctx context.Context, ids ...string) ([]*Comment, *Response, error) {
+	t, resp, err := s.getThings(ctx, toFullnames(kindComment, ids), s.info)
+	if err != nil {
+		return nil, resp, err
+	}
+	return t.Comments, resp, nil
+}
+
+// GetSubreddits returns subreddits from their full IDs or ID36s.
+// Like Get, it requests the IDs 100 at a time and reports the missing ones in the response.
+func (s *ListingsService) GetSubreddits(ctx context.Context, ids ...string) ([]*Subreddit, *Response, error) {
+	t, resp, err := s.getThings(ctx, toFullnames(kindSubreddit, ids), s.info)
 	if err != nil {
 		return nil, resp, err
 	}
-	return l.Posts(), resp, nil
+	return t.Subreddits, resp, nil
+}
+
+func (s *ListingsService) info(ctx context.Context, ids []string) (*listing, *Response, error) {
+	path := "api/info"
+	params := struct {
+		IDs []string `url:"id,omitempty,comma"`
+	}{ids}
+	return s.client.getListing(ctx, path, params)
+}
+
+// getThings gets the things with the full IDs, at most 100 at a time, and sorts them in the order of the IDs.
+func (s *ListingsService) getThings(ctx context.Context, ids []string, get func(context.Context, []string) (*listing, *Response, error)) (*things, *Response, error) {
+	order := make(map[string]int)
+	var unique []string
+	for _, id := range ids {
+		if _, ok := order[id]; !ok {
+			order[id] = len(unique)
+			unique = append(unique, id)
+		}
+	}
+
+	chunks := chunkIDs(unique, maxListingIDs)
+	if len(chunks) == 0 {
+		chunks = [][]string{nil}
+	}
+
+	listings := make([]*listing, len(chunks))
+	responses := make([]*Response, len(chunks))
+
+	// the first error is kept along with its response: once a chunk fails, the others in flight
+	// are canceled, and their errors are caused by the failure
+	var (
+		mu       sync.Mutex
+		firstErr error
+		errResp  *Response
+	)
+	fail := func(resp *Response, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr == nil {
+			firstErr, errResp = err, resp
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	concurrency := s.client.listingsConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, chunk := range chunks {
+		sem <- struct{}{}
+		// the chunks left are never requested
+		if err := ctx.Err(); err != nil {
+			fail(nil, err)
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, chunk []string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			var err error
+			listings[i], responses[i], err = get(ctx, chunk)
+			if err != nil {
+				fail(responses[i], err)
+				cancel()
+			}
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, errResp, firstErr
+	}
+
+	result := new(things)
+	for _, l := range listings {
+		result.Posts = append(result.Posts, l.Posts()...)
+		result.Comments = append(result.Comments, l.Comments()...)
+		result.Subreddits = append(result.Subreddits, l.Subreddits()...)
+	}
+
+	// things that weren't requested, if any, go last
+	index := func(id string) int {
+		if i, ok := order[id]; ok {
+			return i
+		}
+		return len(order)
+	}
+	sort.SliceStable(result.Posts, func(i, j int) bool {
+		return index(result.Posts[i].FullID) < index(result.Posts[j].FullID)
+	})
+	sort.SliceStable(result.Comments, func(i, j int) bool {
+		return index(result.Comments[i].FullID) < index(result.Comments[j].FullID)
+	})
+	sort.SliceStable(result.Subreddits, func(i, j int) bool {
+		return index(result.Subreddits[i].FullID) < index(result.Subreddits[j].FullID)
+	})
+
+	returned := make(map[string]bool)
+	for _, post := range result.Posts {
+		returned[post.FullID] = true
+	}
+	for _, comment := range result.Comments {
+		returned[comment.FullID] = true
+	}
+	for _, subreddit := range result.Subreddits {
+		returned[subreddit.FullID] = true
+	}
+
+	resp := responses[len(responses)-1]
+	for _, id := range unique {
+		if !returned[id] {
+			resp.Missing = append(resp.Missing, id)
+		}
+	}
+
+	return result, resp, nil
 }
@@ -1,9 +1,13 @@
 package reddit
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -177,7 +181,165 @@ func TestListingsService_GetPosts(t *testing.T) {
 		fmt.Fprint(w, blob)
 	})
 
-	posts, _, err := client.Listings.GetPosts(ctx, "t3_i2gvg4", "t3_i2gwgz")
+	posts, resp, err := client.Listings.GetPosts(ctx, "t3_i2gvg4", "t3_i2gwgz")
 	require.NoError(t, err)
 	require.Equal(t, expectedListingPosts2, posts)
+	require.Equal(t, []string{"t3_i2gwgz"}, resp.Missing)
+}
+
+func TestListingsService_GetComments(t *testing.T) {
+	client, mux := setup(t)
+
+	blob, err := readFileContents("../testdata/listings/posts-comments-subreddits.json")
+	require.NoError(t, err)
+
+	mux.HandleFunc("/api/info", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "t1_g05v931", r.Form.Get("id"))
+		fmt.Fprint(w, blob)
+	})
+
+	comments, resp, err := client.Listings.GetComments(ctx, "t1_g05v931")
+	require.NoError(t, err)
+	require.Equal(t, expectedListingComments, comments)
+	require.Empty(t, resp.Missing)
+}
+
+func TestListingsService_GetSubreddits(t *testing.T) {
+	client, mux := setup(t)
+
+	blob, err := readFileContents("../testdata/listings/posts-comments-subreddits.json")
+	require.NoError(t, err)
+
+	mux.HandleFunc("/api/info", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "t5_2qh23,t5_deleted", r.Form.Get("id"))
+		fmt.Fprint(w, blob)
+	})
+
+	subreddits, resp, err := client.Listings.GetSubreddits(ctx, "t5_2qh23", "t5_deleted")
+	require.NoError(t, err)
+	require.Equal(t, expectedListingSubreddits, subreddits)
+	require.Equal(t, []string{"t5_deleted"}, resp.Missing)
+}
+
+func TestListingsService_Get_Chunked(t *testing.T) {
+	client, mux := setup(t)
+	require.NoError(t, WithListingsConcurrency(2)(client))
+
+	var mu sync.Mutex
+	var requests int
+	mux.HandleFunc("/api/info", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		ids := strings.Split(r.Form.Get("id"), ",")
+		require.LessOrEqual(t, len(ids), 100)
+
+		mu.Lock()
+		requests++
+		mu.Unlock()
+
+		// the things come back in reverse order, and the deleted ones are left out
+		var returned []string
+		for i := len(ids) - 1; i >= 0; i-- {
+			if !strings.HasSuffix(ids[i], "deleted") {
+				returned = append(returned, ids[i])
+			}
+		}
+		fmt.Fprint(w, thingListing(returned...))
+	})
+
+	var ids []string
+	for i := 0; i < 240; i++ {
+		ids = append(ids, "t3_"+strconv.Itoa(i), "t1_"+strconv.Itoa(i))
+	}
+	ids = append(ids, "t3_deleted", "t3_0")
+
+	posts, comments, _, resp, err := client.Listings.Get(ctx, ids...)
+	require.NoError(t, err)
+	require.Equal(t, 5, requests)
+	require.Len(t, posts, 240)
+	require.Len(t, comments, 240)
+	for i := 0; i < 240; i++ {
+		require.Equal(t, "t3_"+strconv.Itoa(i), posts[i].FullID)
+		require.Equal(t, "t1_"+strconv.Itoa(i), comments[i].FullID)
+	}
+	require.Equal(t, []string{"t3_deleted"}, resp.Missing)
+}
+
+func TestListingsService_Get_ChunkError(t *testing.T) {
+	client, mux := setup(t)
+
+	var requests int
+	mux.HandleFunc("/api/info", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprint(w, `{"kind": "Listing", "data": {"children": []}}`)
+	})
+
+	ids := make([]string, 300)
+	for i := range ids {
+		ids[i] = "t3_" + strconv.Itoa(i)
+	}
+
+	_, _, _, resp, err := client.Listings.Get(ctx, ids...)
+	require.IsType(t, &ErrorResponse{}, err)
+	require.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	require.Equal(t, 2, requests)
+}
+
+func TestListingsService_Get_ChunkError_Concurrent(t *testing.T) {
+	client, mux := setup(t)
+	require.NoError(t, WithListingsConcurrency(2)(client))
+
+	failed := make(chan struct{})
+	mux.HandleFunc("/api/info", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		if strings.HasPrefix(r.Form.Get("id"), "t3_100,") {
+			defer close(failed)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		// the first chunk is still in flight when the second one fails, and gets canceled
+		<-failed
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second * 5):
+			t.Error("the request should have been canceled")
+		}
+	})
+
+	ids := make([]string, 150)
+	for i := range ids {
+		ids[i] = "t3_" + strconv.Itoa(i)
+	}
+
+	_, _, _, resp, err := client.Listings.Get(ctx, ids...)
+	require.IsType(t, &ErrorResponse{}, err)
+	require.NotNil(t, resp)
+	require.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+}
+
+func TestListingsService_Get_Canceled(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/api/info", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request should be made")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, _, resp, err := client.Listings.Get(ctx, "t3_abc")
+	require.Equal(t, context.Canceled, err)
+	require.Nil(t, resp)
+
+	_, resp, err = client.Listings.GetPosts(ctx, "t3_abc")
+	require.Equal(t, context.Canceled, err)
+	require.Nil(t, resp)
 }
@@ -555,11 +555,11 @@ func (s *LiveThreadService) Revoke(ctx context.Context, threadID, userID string)
 }
 
 // HideDiscussion hides a linked post from the live thread's discussion sidebar.
-// The postID should be the base36 ID of the post, i.e. not its full id.
+// The postID is either the full ID of the post or its ID36, e.g. t3_abc123 or abc123.
 func (s *LiveThreadService) HideDiscussion(ctx context.Context, threadID, postID string) (*Response, error) {
 	form := url.Values{}
 	form.Set("api_type", "json")
-	form.Set("link", postID)
+	form.Set("link", toFullname(kindPost, postID))
 
 	path := fmt.Sprintf("api/live/%s/hide_discussion", threadID)
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
@@ -571,11 +571,11 @@ func (s *LiveThreadService) HideDiscussion(ctx context.Context, threadID, postID
 }
 
 // UnhideDiscussion unhides a linked post from the live thread's discussion sidebar.
-// The postID should be the base36 ID of the post, i.e. not its full id.
+// The postID is either the full ID of the post or its ID36, e.g. t3_abc123 or abc123.
 func (s *LiveThreadService) UnhideDiscussion(ctx context.Context, threadID, postID string) (*Response, error) {
 	form := url.Values{}
 	form.Set("api_type", "json")
-	form.Set("link", postID)
+	form.Set("link", toFullname(kindPost, postID))
 
 	path := fmt.Sprintf("api/live/%s/unhide_discussion", threadID)
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
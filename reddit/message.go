@@ -33,6 +33,7 @@ type Message struct {
 	To     string `json:"dest"`
 
 	IsComment bool `json:"was_comment"`
+	Unread    bool `json:"new"`
 }
 
 type inboxThing struct {
@@ -42,19 +43,15 @@ type inboxThing struct {
 
 type inboxListing struct {
 	inboxThings
-	after string
-}
-
-func (l *inboxListing) After() string {
-	return l.after
+	anchors
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
 func (l *inboxListing) UnmarshalJSON(b []byte) error {
 	root := new(struct {
 		Data struct {
+			anchorsJSON
 			Things inboxThings `json:"children"`
-			After  string      `json:"after"`
 		} `json:"data"`
 	})
 
@@ -64,7 +61,7 @@ func (l *inboxListing) UnmarshalJSON(b []byte) error {
 	}
 
 	l.inboxThings = root.Data.Things
-	l.after = root.Data.After
+	l.anchors = root.Data.anchors(len(root.Data.Things.Comments) + len(root.Data.Things.Messages))
 
 	return nil
 }
@@ -72,6 +69,8 @@ func (l *inboxListing) UnmarshalJSON(b []byte) error {
 type inboxThings struct {
 	Comments []*Message
 	Messages []*Message
+	// both comments and messages, in the order of the listing
+	all []*Message
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
@@ -92,7 +91,10 @@ func (t *inboxThings) add(things ...inboxThing) {
 			t.Comments = append(t.Comments, thing.Data)
 		case kindMessage:
 			t.Messages = append(t.Messages, thing.Data)
+		default:
+			continue
 		}
+		t.all = append(t.all, thing.Data)
 	}
 }
 
@@ -124,6 +126,10 @@ func (s *MessageService) Read(ctx context.Context, ids ...string) (*Response, er
 		return nil, errors.New("must provide at least 1 id")
 	}
 
+	if err := validateFullnames(ids...); err != nil {
+		return nil, err
+	}
+
 	path := "api/read_message"
 
 	form := url.Values{}
@@ -143,6 +149,10 @@ func (s *MessageService) Unread(ctx context.Context, ids ...string) (*Response,
 		return nil, errors.New("must provide at least 1 id")
 	}
 
+	if err := validateFullnames(ids...); err != nil {
+		return nil, err
+	}
+
 	path := "api/unread_message"
 
 	form := url.Values{}
@@ -158,6 +168,10 @@ func (s *MessageService) Unread(ctx context.Context, ids ...string) (*Response,
 
 // Block the author of a post, comment or message via its full ID.
 func (s *MessageService) Block(ctx context.Context, id string) (*Response, error) {
+	if err := validateFullnames(id); err != nil {
+		return nil, err
+	}
+
 	path := "api/block"
 
 	form := url.Values{}
@@ -171,7 +185,7 @@ func (s *MessageService) Block(ctx context.Context, id string) (*Response, error
 	return s.client.Do(ctx, req, nil)
 }
 
-// Collapse messages.
+// Collapse messages via their full IDs or ID36s.
 func (s *MessageService) Collapse(ctx context.Context, ids ...string) (*Response, error) {
 	if len(ids) == 0 {
 		return nil, errors.New("must provide at least 1 id")
@@ -180,7 +194,7 @@ func (s *MessageService) Collapse(ctx context.Context, ids ...string) (*Response
 	path := "api/collapse_message"
 
 	form := url.Values{}
-	form.Set("id", strings.Join(ids, ","))
+	form.Set("id", strings.Join(toFullnames(kindMessage, ids), ","))
 
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
 	if err != nil {
@@ -190,7 +204,7 @@ func (s *MessageService) Collapse(ctx context.Context, ids ...string) (*Response
 	return s.client.Do(ctx, req, nil)
 }
 
-// Uncollapse messages.
+// Uncollapse messages via their full IDs or ID36s.
 func (s *MessageService) Uncollapse(ctx context.Context, ids ...string) (*Response, error) {
 	if len(ids) == 0 {
 		return nil, errors.New("must provide at least 1 id")
@@ -199,7 +213,7 @@ func (s *MessageService) Uncollapse(ctx context.Context, ids ...string) (*Respon
 	path := "api/uncollapse_message"
 
 	form := url.Values{}
-	form.Set("id", strings.Join(ids, ","))
+	form.Set("id", strings.Join(toFullnames(kindMessage, ids), ","))
 
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
 	if err != nil {
@@ -209,12 +223,12 @@ func (s *MessageService) Uncollapse(ctx context.Context, ids ...string) (*Respon
 	return s.client.Do(ctx, req, nil)
 }
 
-// Delete a message.
+// Delete a message via its full ID or ID36.
 func (s *MessageService) Delete(ctx context.Context, id string) (*Response, error) {
 	path := "api/del_msg"
 
 	form := url.Values{}
-	form.Set("id", id)
+	form.Set("id", toFullname(kindMessage, id))
 
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
 	if err != nil {
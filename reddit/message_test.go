@@ -64,7 +64,7 @@ func TestMessageService_Read(t *testing.T) {
 		require.Equal(t, http.MethodPost, r.Method)
 
 		form := url.Values{}
-		form.Set("id", "test1,test2,test3")
+		form.Set("id", "t4_test1,t1_test2,t4_test3")
 
 		err := r.ParseForm()
 		require.NoError(t, err)
@@ -74,7 +74,10 @@ func TestMessageService_Read(t *testing.T) {
 	_, err := client.Message.Read(ctx)
 	require.EqualError(t, err, "must provide at least 1 id")
 
-	_, err = client.Message.Read(ctx, "test1", "test2", "test3")
+	_, err = client.Message.Read(ctx, "t4_test1", "test2")
+	require.EqualError(t, err, `invalid fullname "test2": missing kind`)
+
+	_, err = client.Message.Read(ctx, "t4_test1", "t1_test2", "t4_test3")
 	require.NoError(t, err)
 }
 
@@ -85,7 +88,7 @@ func TestMessageService_Unread(t *testing.T) {
 		require.Equal(t, http.MethodPost, r.Method)
 
 		form := url.Values{}
-		form.Set("id", "test1,test2,test3")
+		form.Set("id", "t4_test1,t1_test2,t4_test3")
 
 		err := r.ParseForm()
 		require.NoError(t, err)
@@ -95,7 +98,10 @@ func TestMessageService_Unread(t *testing.T) {
 	_, err := client.Message.Unread(ctx)
 	require.EqualError(t, err, "must provide at least 1 id")
 
-	_, err = client.Message.Unread(ctx, "test1", "test2", "test3")
+	_, err = client.Message.Unread(ctx, "t4_test1", "test2")
+	require.EqualError(t, err, `invalid fullname "test2": missing kind`)
+
+	_, err = client.Message.Unread(ctx, "t4_test1", "t1_test2", "t4_test3")
 	require.NoError(t, err)
 }
 
@@ -106,7 +112,7 @@ func TestMessageService_Block(t *testing.T) {
 		require.Equal(t, http.MethodPost, r.Method)
 
 		form := url.Values{}
-		form.Set("id", "test")
+		form.Set("id", "t1_test")
 
 		err := r.ParseForm()
 		require.NoError(t, err)
@@ -114,6 +120,9 @@ func TestMessageService_Block(t *testing.T) {
 	})
 
 	_, err := client.Message.Block(ctx, "test")
+	require.EqualError(t, err, `invalid fullname "test": missing kind`)
+
+	_, err = client.Message.Block(ctx, "t1_test")
 	require.NoError(t, err)
 }
 
@@ -124,7 +133,7 @@ func TestMessageService_Collapse(t *testing.T) {
 		require.Equal(t, http.MethodPost, r.Method)
 
 		form := url.Values{}
-		form.Set("id", "test1,test2,test3")
+		form.Set("id", "t4_test1,t4_test2,t4_test3")
 
 		err := r.ParseForm()
 		require.NoError(t, err)
@@ -134,7 +143,7 @@ func TestMessageService_Collapse(t *testing.T) {
 	_, err := client.Message.Collapse(ctx)
 	require.EqualError(t, err, "must provide at least 1 id")
 
-	_, err = client.Message.Collapse(ctx, "test1", "test2", "test3")
+	_, err = client.Message.Collapse(ctx, "t4_test1", "test2", "test3")
 	require.NoError(t, err)
 }
 
@@ -145,7 +154,7 @@ func TestMessageService_Uncollapse(t *testing.T) {
 		require.Equal(t, http.MethodPost, r.Method)
 
 		form := url.Values{}
-		form.Set("id", "test1,test2,test3")
+		form.Set("id", "t4_test1,t4_test2,t4_test3")
 
 		err := r.ParseForm()
 		require.NoError(t, err)
@@ -155,7 +164,7 @@ func TestMessageService_Uncollapse(t *testing.T) {
 	_, err := client.Message.Uncollapse(ctx)
 	require.EqualError(t, err, "must provide at least 1 id")
 
-	_, err = client.Message.Uncollapse(ctx, "test1", "test2", "test3")
+	_, err = client.Message.Uncollapse(ctx, "t4_test1", "test2", "test3")
 	require.NoError(t, err)
 }
 
@@ -166,7 +175,7 @@ func TestMessageService_Delete(t *testing.T) {
 		require.Equal(t, http.MethodPost, r.Method)
 
 		form := url.Values{}
-		form.Set("id", "test")
+		form.Set("id", "t4_test")
 
 		err := r.ParseForm()
 		require.NoError(t, err)
@@ -218,10 +227,11 @@ func TestMessageService_Inbox(t *testing.T) {
 		fmt.Fprint(w, blob)
 	})
 
-	comments, messages, _, err := client.Message.Inbox(ctx, nil)
+	comments, messages, resp, err := client.Message.Inbox(ctx, nil)
 	require.NoError(t, err)
 	require.Equal(t, expectedCommentMessages, comments)
 	require.Equal(t, expectedMessages, messages)
+	require.Equal(t, 2, resp.Dist)
 }
 
 func TestMessageService_InboxUnread(t *testing.T) {
@@ -126,6 +126,10 @@ func (s *ModerationService) AcceptInvite(ctx context.Context, subreddit string)
 
 // Approve a post or comment via its full ID.
 func (s *ModerationService) Approve(ctx context.Context, id string) (*Response, error) {
+	if err := validateFullnames(id); err != nil {
+		return nil, err
+	}
+
 	path := "api/approve"
 
 	form := url.Values{}
@@ -141,6 +145,10 @@ func (s *ModerationService) Approve(ctx context.Context, id string) (*Response,
 
 // Remove a post, comment or modmail message via its full ID.
 func (s *ModerationService) Remove(ctx context.Context, id string) (*Response, error) {
+	if err := validateFullnames(id); err != nil {
+		return nil, err
+	}
+
 	path := "api/remove"
 
 	form := url.Values{}
@@ -157,6 +165,10 @@ func (s *ModerationService) Remove(ctx context.Context, id string) (*Response, e
 
 // RemoveSpam removes a post, comment or modmail message via its full ID and marks it as spam.
 func (s *ModerationService) RemoveSpam(ctx context.Context, id string) (*Response, error) {
+	if err := validateFullnames(id); err != nil {
+		return nil, err
+	}
+
 	path := "api/remove"
 
 	form := url.Values{}
@@ -171,12 +183,12 @@ func (s *ModerationService) RemoveSpam(ctx context.Context, id string) (*Respons
 	return s.client.Do(ctx, req, nil)
 }
 
-// Leave abdicates your moderator status in a subreddit via its full ID.
+// Leave abdicates your moderator status in a subreddit via its full ID or ID36.
 func (s *ModerationService) Leave(ctx context.Context, subredditID string) (*Response, error) {
 	path := "api/leavemoderator"
 
 	form := url.Values{}
-	form.Set("id", subredditID)
+	form.Set("id", toFullname(kindSubreddit, subredditID))
 
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
 	if err != nil {
@@ -186,12 +198,12 @@ func (s *ModerationService) Leave(ctx context.Context, subredditID string) (*Res
 	return s.client.Do(ctx, req, nil)
 }
 
-// LeaveContributor abdicates your approved user status in a subreddit via its full ID.
+// LeaveContributor abdicates your approved user status in a subreddit via its full ID or ID36.
 func (s *ModerationService) LeaveContributor(ctx context.Context, subredditID string) (*Response, error) {
 	path := "api/leavecontributor"
 
 	form := url.Values{}
-	form.Set("id", subredditID)
+	form.Set("id", toFullname(kindSubreddit, subredditID))
 
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
 	if err != nil {
@@ -254,6 +266,10 @@ func (s *ModerationService) Edited(ctx context.Context, subreddit string, opts *
 
 // IgnoreReports prevents reports on a post or comment from causing notifications.
 func (s *ModerationService) IgnoreReports(ctx context.Context, id string) (*Response, error) {
+	if err := validateFullnames(id); err != nil {
+		return nil, err
+	}
+
 	path := "api/ignore_reports"
 
 	form := url.Values{}
@@ -269,6 +285,10 @@ func (s *ModerationService) IgnoreReports(ctx context.Context, id string) (*Resp
 
 // UnignoreReports allows reports on a post or comment to cause notifications.
 func (s *ModerationService) UnignoreReports(ctx context.Context, id string) (*Response, error) {
+	if err := validateFullnames(id); err != nil {
+		return nil, err
+	}
+
 	path := "api/unignore_reports"
 
 	form := url.Values{}
@@ -442,6 +462,10 @@ func (s *ModerationService) deleteRelationship(ctx context.Context, subreddit, u
 // Distinguish your post or comment via its full ID, adding a moderator tag to it.
 // todo: add how=admin and how=special? They require special privileges.
 func (s *ModerationService) Distinguish(ctx context.Context, id string) (*Response, error) {
+	if err := validateFullnames(id); err != nil {
+		return nil, err
+	}
+
 	path := "api/distinguish"
 
 	form := url.Values{}
@@ -460,6 +484,10 @@ func (s *ModerationService) Distinguish(ctx context.Context, id string) (*Respon
 // DistinguishAndSticky your comment via its full ID, adding a moderator tag to it
 // and stickying the comment at the top of the thread.
 func (s *ModerationService) DistinguishAndSticky(ctx context.Context, id string) (*Response, error) {
+	if err := validateFullnames(id); err != nil {
+		return nil, err
+	}
+
 	path := "api/distinguish"
 
 	form := url.Values{}
@@ -478,6 +506,10 @@ func (s *ModerationService) DistinguishAndSticky(ctx context.Context, id string)
 
 // Undistinguish your post or comment via its full ID, removing the moderator tag from it.
 func (s *ModerationService) Undistinguish(ctx context.Context, id string) (*Response, error) {
+	if err := validateFullnames(id); err != nil {
+		return nil, err
+	}
+
 	path := "api/distinguish"
 
 	form := url.Values{}
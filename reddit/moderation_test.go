@@ -108,7 +108,10 @@ func TestModerationService_Approve(t *testing.T) {
 		require.Equal(t, form, r.PostForm)
 	})
 
-	_, err := client.Moderation.Approve(ctx, "t3_test")
+	_, err := client.Moderation.Approve(ctx, "test")
+	require.EqualError(t, err, `invalid fullname "test": missing kind`)
+
+	_, err = client.Moderation.Approve(ctx, "t3_test")
 	require.NoError(t, err)
 }
 
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 )
 
 // postAndCommentService handles communication with the post and comment
@@ -14,6 +15,10 @@ import (
 // Reddit API docs: https://www.reddit.com/dev/api/#section_links_and_comments
 type postAndCommentService struct {
 	client *Client
+	// The name of the service embedding it, e.g. Post.
+	service string
+	// The kind of the things of the service, used to accept their ID36s as well as their full IDs.
+	kind string
 }
 
 type vote int
@@ -25,19 +30,19 @@ const (
 	upvote
 )
 
-// Delete a post or comment via its full ID.
+// Delete a post or comment via its full ID or ID36.
 func (s *postAndCommentService) Delete(ctx context.Context, id string) (*Response, error) {
 	path := "api/del"
 
 	form := url.Values{}
-	form.Set("id", id)
+	form.Set("id", toFullname(s.kind, id))
 
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.client.Do(ctx, req, nil)
+	return s.do(ctx, req)
 }
 
 // Save a post or comment.
@@ -45,14 +50,14 @@ func (s *postAndCommentService) Save(ctx context.Context, id string) (*Response,
 	path := "api/save"
 
 	form := url.Values{}
-	form.Set("id", id)
+	form.Set("id", toFullname(s.kind, id))
 
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.client.Do(ctx, req, nil)
+	return s.do(ctx, req)
 }
 
 // Unsave a post or comment.
@@ -60,14 +65,14 @@ func (s *postAndCommentService) Unsave(ctx context.Context, id string) (*Respons
 	path := "api/unsave"
 
 	form := url.Values{}
-	form.Set("id", id)
+	form.Set("id", toFullname(s.kind, id))
 
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.client.Do(ctx, req, nil)
+	return s.do(ctx, req)
 }
 
 // EnableReplies enables inbox replies for one of your posts or comments.
@@ -75,7 +80,7 @@ func (s *postAndCommentService) EnableReplies(ctx context.Context, id string) (*
 	path := "api/sendreplies"
 
 	form := url.Values{}
-	form.Set("id", id)
+	form.Set("id", toFullname(s.kind, id))
 	form.Set("state", "true")
 
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
@@ -83,7 +88,7 @@ func (s *postAndCommentService) EnableReplies(ctx context.Context, id string) (*
 		return nil, err
 	}
 
-	return s.client.Do(ctx, req, nil)
+	return s.do(ctx, req)
 }
 
 // DisableReplies dsables inbox replies for one of your posts or comments.
@@ -91,7 +96,7 @@ func (s *postAndCommentService) DisableReplies(ctx context.Context, id string) (
 	path := "api/sendreplies"
 
 	form := url.Values{}
-	form.Set("id", id)
+	form.Set("id", toFullname(s.kind, id))
 	form.Set("state", "false")
 
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
@@ -99,7 +104,7 @@ func (s *postAndCommentService) DisableReplies(ctx context.Context, id string) (
 		return nil, err
 	}
 
-	return s.client.Do(ctx, req, nil)
+	return s.do(ctx, req)
 }
 
 // Lock a post or comment, preventing it from receiving new comments.
@@ -107,14 +112,14 @@ func (s *postAndCommentService) Lock(ctx context.Context, id string) (*Response,
 	path := "api/lock"
 
 	form := url.Values{}
-	form.Set("id", id)
+	form.Set("id", toFullname(s.kind, id))
 
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.client.Do(ctx, req, nil)
+	return s.do(ctx, req)
 }
 
 // Unlock a post or comment, allowing it to receive new comments.
@@ -122,21 +127,21 @@ func (s *postAndCommentService) Unlock(ctx context.Context, id string) (*Respons
 	path := "api/unlock"
 
 	form := url.Values{}
-	form.Set("id", id)
+	form.Set("id", toFullname(s.kind, id))
 
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.client.Do(ctx, req, nil)
+	return s.do(ctx, req)
 }
 
 func (s *postAndCommentService) vote(ctx context.Context, id string, vote vote) (*Response, error) {
 	path := "api/vote"
 
 	form := url.Values{}
-	form.Set("id", id)
+	form.Set("id", toFullname(s.kind, id))
 	form.Set("dir", strconv.Itoa(int(vote)))
 	form.Set("rank", "10")
 
@@ -145,7 +150,7 @@ func (s *postAndCommentService) vote(ctx context.Context, id string, vote vote)
 		return nil, err
 	}
 
-	return s.client.Do(ctx, req, nil)
+	return s.do(ctx, req)
 }
 
 // Upvote a post or a comment.
@@ -170,7 +175,7 @@ func (s *postAndCommentService) Report(ctx context.Context, id string, reason st
 
 	form := url.Values{}
 	form.Set("api_type", "json")
-	form.Set("thing_id", id)
+	form.Set("thing_id", toFullname(s.kind, id))
 	form.Set("reason", reason)
 
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
@@ -178,5 +183,16 @@ func (s *postAndCommentService) Report(ctx context.Context, id string, reason st
 		return nil, err
 	}
 
+	return s.do(ctx, req)
+}
+
+// do sends the request, attributing it to the method of the service embedding s, e.g. Post.Upvote
+// rather than postAndComment.Upvote.
+func (s *postAndCommentService) do(ctx context.Context, req *http.Request) (*Response, error) {
+	if len(s.client.observers) > 0 && operationFromContext(ctx) == "" {
+		if operation := callerOperation(); strings.HasPrefix(operation, "postAndComment.") {
+			ctx = withOperation(ctx, s.service+strings.TrimPrefix(operation, "postAndComment"))
+		}
+	}
 	return s.client.Do(ctx, req, nil)
 }
@@ -63,10 +63,9 @@ type SubmitLinkRequest struct {
 }
 
 // Get a post with its comments.
-// id is the ID36 of the post, not its full id.
-// Example: instead of t3_abc123, use abc123.
+// id is either the ID36 of the post or its full ID, e.g. abc123 or t3_abc123.
 func (s *PostService) Get(ctx context.Context, id string) (*PostAndComments, *Response, error) {
-	path := fmt.Sprintf("comments/%s", id)
+	path := fmt.Sprintf("comments/%s", toID36(id))
 	req, err := s.client.NewRequest(http.MethodGet, path, nil)
 	if err != nil {
 		return nil, nil, err
@@ -82,10 +81,9 @@ func (s *PostService) Get(ctx context.Context, id string) (*PostAndComments, *Re
 }
 
 // Duplicates returns the post with the id, and a list of its duplicates.
-// id is the ID36 of the post, not its full id.
-// Example: instead of t3_abc123, use abc123.
+// id is either the ID36 of the post or its full ID, e.g. abc123 or t3_abc123.
 func (s *PostService) Duplicates(ctx context.Context, id string, opts *ListDuplicatePostOptions) (*Post, []*Post, *Response, error) {
-	path := fmt.Sprintf("duplicates/%s", id)
+	path := fmt.Sprintf("duplicates/%s", toID36(id))
 	path, err := addOptions(path, opts)
 	if err != nil {
 		return nil, nil, nil, err
@@ -108,7 +106,7 @@ func (s *PostService) Duplicates(ctx context.Context, id string, opts *ListDupli
 	post := listing1.Posts()[0]
 	duplicates := listing2.Posts()
 
-	resp.After = listing2.After()
+	resp.populateAnchors(listing2)
 	return post, duplicates, resp, nil
 }
 
@@ -160,7 +158,7 @@ func (s *PostService) Edit(ctx context.Context, id string, text string) (*Post,
 	form := url.Values{}
 	form.Set("api_type", "json")
 	form.Set("return_rtjson", "true")
-	form.Set("thing_id", id)
+	form.Set("thing_id", toFullname(kindPost, id))
 	form.Set("text", text)
 
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
@@ -186,7 +184,7 @@ func (s *PostService) Hide(ctx context.Context, ids ...string) (*Response, error
 	path := "api/hide"
 
 	form := url.Values{}
-	form.Set("id", strings.Join(ids, ","))
+	form.Set("id", strings.Join(toFullnames(kindPost, ids), ","))
 
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
 	if err != nil {
@@ -205,7 +203,7 @@ func (s *PostService) Unhide(ctx context.Context, ids ...string) (*Response, err
 	path := "api/unhide"
 
 	form := url.Values{}
-	form.Set("id", strings.Join(ids, ","))
+	form.Set("id", strings.Join(toFullnames(kindPost, ids), ","))
 
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
 	if err != nil {
@@ -220,7 +218,7 @@ func (s *PostService) MarkNSFW(ctx context.Context, id string) (*Response, error
 	path := "api/marknsfw"
 
 	form := url.Values{}
-	form.Set("id", id)
+	form.Set("id", toFullname(kindPost, id))
 
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
 	if err != nil {
@@ -235,7 +233,7 @@ func (s *PostService) UnmarkNSFW(ctx context.Context, id string) (*Response, err
 	path := "api/unmarknsfw"
 
 	form := url.Values{}
-	form.Set("id", id)
+	form.Set("id", toFullname(kindPost, id))
 
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
 	if err != nil {
@@ -250,7 +248,7 @@ func (s *PostService) Spoiler(ctx context.Context, id string) (*Response, error)
 	path := "api/spoiler"
 
 	form := url.Values{}
-	form.Set("id", id)
+	form.Set("id", toFullname(kindPost, id))
 
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
 	if err != nil {
@@ -265,7 +263,7 @@ func (s *PostService) Unspoiler(ctx context.Context, id string) (*Response, erro
 	path := "api/unspoiler"
 
 	form := url.Values{}
-	form.Set("id", id)
+	form.Set("id", toFullname(kindPost, id))
 
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
 	if err != nil {
@@ -284,7 +282,7 @@ func (s *PostService) Sticky(ctx context.Context, id string, bottom bool) (*Resp
 
 	form := url.Values{}
 	form.Set("api_type", "json")
-	form.Set("id", id)
+	form.Set("id", toFullname(kindPost, id))
 	form.Set("state", "true")
 	if !bottom {
 		form.Set("num", "1")
@@ -304,7 +302,7 @@ func (s *PostService) Unsticky(ctx context.Context, id string) (*Response, error
 
 	form := url.Values{}
 	form.Set("api_type", "json")
-	form.Set("id", id)
+	form.Set("id", toFullname(kindPost, id))
 	form.Set("state", "false")
 
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
@@ -334,7 +332,7 @@ func (s *PostService) PinToProfile(ctx context.Context, id string) (*Response, e
 
 	form := url.Values{}
 	form.Set("api_type", "json")
-	form.Set("id", id)
+	form.Set("id", toFullname(kindPost, id))
 	form.Set("state", "true")
 	form.Set("to_profile", "true")
 	// form.Set("num", strconv.Itoa(pos))
@@ -353,7 +351,7 @@ func (s *PostService) UnpinFromProfile(ctx context.Context, id string) (*Respons
 
 	form := url.Values{}
 	form.Set("api_type", "json")
-	form.Set("id", id)
+	form.Set("id", toFullname(kindPost, id))
 	form.Set("state", "false")
 	form.Set("to_profile", "true")
 
@@ -372,7 +370,7 @@ func (s *PostService) setSuggestedSort(ctx context.Context, id string, sort stri
 
 	form := url.Values{}
 	form.Set("api_type", "json")
-	form.Set("id", id)
+	form.Set("id", toFullname(kindPost, id))
 	form.Set("sort", sort)
 
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
@@ -436,7 +434,7 @@ func (s *PostService) EnableContestMode(ctx context.Context, id string) (*Respon
 
 	form := url.Values{}
 	form.Set("api_type", "json")
-	form.Set("id", id)
+	form.Set("id", toFullname(kindPost, id))
 	form.Set("state", "true")
 
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
@@ -453,7 +451,7 @@ func (s *PostService) DisableContestMode(ctx context.Context, id string) (*Respo
 
 	form := url.Values{}
 	form.Set("api_type", "json")
-	form.Set("id", id)
+	form.Set("id", toFullname(kindPost, id))
 	form.Set("state", "false")
 
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
@@ -571,7 +569,7 @@ func (s *PostService) MarkVisited(ctx context.Context, ids ...string) (*Response
 	path := "api/store_visits"
 
 	form := url.Values{}
-	form.Set("links", strings.Join(ids, ","))
+	form.Set("links", strings.Join(toFullnames(kindPost, ids), ","))
 
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
 	if err != nil {
@@ -225,6 +225,10 @@ func TestPostService_Get(t *testing.T) {
 	postAndComments, _, err := client.Post.Get(ctx, "abc123")
 	require.NoError(t, err)
 	require.Equal(t, expectedPostAndComments, postAndComments)
+
+	postAndComments, _, err = client.Post.Get(ctx, "t3_abc123")
+	require.NoError(t, err)
+	require.Equal(t, expectedPostAndComments, postAndComments)
 }
 
 func TestPostService_Duplicates(t *testing.T) {
@@ -365,7 +369,7 @@ func TestPostService_Hide(t *testing.T) {
 		require.Equal(t, http.MethodPost, r.Method)
 
 		form := url.Values{}
-		form.Set("id", "1,2,3")
+		form.Set("id", "t3_1,t3_2,t3_3")
 
 		err := r.ParseForm()
 		require.NoError(t, err)
@@ -387,7 +391,7 @@ func TestPostService_Unhide(t *testing.T) {
 		require.Equal(t, http.MethodPost, r.Method)
 
 		form := url.Values{}
-		form.Set("id", "1,2,3")
+		form.Set("id", "t3_1,t3_2,t3_3")
 
 		err := r.ParseForm()
 		require.NoError(t, err)
@@ -1049,6 +1053,10 @@ func TestPostService_Upvote(t *testing.T) {
 	resp, err := client.Post.Upvote(ctx, "t3_test")
 	require.NoError(t, err)
 	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = client.Post.Upvote(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
 }
 
 func TestPostService_Downvote(t *testing.T) {
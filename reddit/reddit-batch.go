@@ -0,0 +1,267 @@
+package reddit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBatchConcurrency      = 4
+	defaultBatchRateLimitRetries = 5
+)
+
+// BatchOperation is an operation run by a Batch, such as approving a post or banning a user.
+// The value it returns, if any, is kept in its BatchResult.
+type BatchOperation func(ctx context.Context, client *Client) (interface{}, *Response, error)
+
+// BatchOptions configures a Batch.
+type BatchOptions struct {
+	// Maximum number of operations running at the same time.
+	// If 0 or less, the default of 4 is used.
+	Concurrency int
+	// If true, the batch stops running new operations once one fails.
+	StopOnError bool
+	// Maximum number of times an operation failing because the rate limit was exceeded
+	// is run again, once the rate limit resets. If 0, the default of 5 is used.
+	// If less than 0, such operations fail right away.
+	RateLimitRetries int
+}
+
+// BatchResult is the outcome of an operation of a Batch.
+type BatchResult struct {
+	Value    interface{}
+	Response *Response
+	Err      error
+	// Whether the operation ran to completion, successfully or not.
+	Done bool
+}
+
+// BatchError is returned by Batch.Run when some of its operations failed.
+type BatchError struct {
+	// Indexes of the operations that failed, in ascending order.
+	Failed []int
+	// Errors of the operations that failed, in the same order.
+	Errors []error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("%d operations of the batch failed, the first one with: %v", len(e.Failed), e.Errors[0])
+}
+
+// Batch runs operations with a client with bounded concurrency, e.g. to approve hundreds of posts.
+// Since they all use the same client, they share its rate limit. Operations failing because the
+// rate limit was exceeded wait until it resets and run again, up to BatchOptions.RateLimitRetries times.
+// To pace the requests instead of bursting until the rate limit is exhausted, configure the client
+// with a rate limiter (see WithRateLimiter).
+//
+//	batch := client.Batch(nil)
+//	for _, id := range ids {
+//		id := id
+//		batch.Add(func(ctx context.Context, client *reddit.Client) (interface{}, *reddit.Response, error) {
+//			resp, err := client.Moderation.Approve(ctx, id)
+//			return nil, resp, err
+//		})
+//	}
+//	err := batch.Run(ctx)
+//
+// A batch can be run again to resume it after a failure or cancellation,
+// in which case only the operations that haven't succeeded yet are run.
+type Batch struct {
+	client *Client
+	opts   BatchOptions
+
+	mu         sync.Mutex
+	running    bool
+	operations []BatchOperation
+	results    []*BatchResult
+}
+
+// Batch returns a new batch of operations run with the client.
+func (c *Client) Batch(opts *BatchOptions) *Batch {
+	b := &Batch{client: c}
+	if opts != nil {
+		b.opts = *opts
+	}
+	if b.opts.Concurrency <= 0 {
+		b.opts.Concurrency = defaultBatchConcurrency
+	}
+	if b.opts.RateLimitRetries == 0 {
+		b.opts.RateLimitRetries = defaultBatchRateLimitRetries
+	}
+	return b
+}
+
+// Add adds the operation to the batch and returns its index.
+func (b *Batch) Add(op BatchOperation) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.operations = append(b.operations, op)
+	b.results = append(b.results, new(BatchResult))
+	return len(b.operations) - 1
+}
+
+// AddGetPosts adds operations getting the posts with the full IDs, and returns their indexes.
+// The IDs are split into chunks of 100, the most Reddit accepts in a single request, and each
+// chunk is fetched by its own operation. The value of each operation's result is a []*Post.
+func (b *Batch) AddGetPosts(ids ...string) []int {
+	var indexes []int
+	for _, chunk := range chunkIDs(ids, maxListingIDs) {
+		chunk := chunk
+		indexes = append(indexes, b.Add(func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+			return client.Listings.GetPosts(ctx, chunk...)
+		}))
+	}
+	return indexes
+}
+
+// Results returns the results of the operations, in the order they were added.
+func (b *Batch) Results() []BatchResult {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	results := make([]BatchResult, len(b.results))
+	for i, result := range b.results {
+		results[i] = *result
+	}
+	return results
+}
+
+// Run runs the operations that haven't succeeded yet, and waits for them to end.
+// It returns a *BatchError if any of them failed, or the context's error if it was canceled
+// before all of them could run. Operations interrupted by the cancellation are run again
+// the next time the batch is run.
+func (b *Batch) Run(ctx context.Context) error {
+	b.mu.Lock()
+	if b.running {
+		b.mu.Unlock()
+		return errors.New("batch is already running")
+	}
+	b.running = true
+
+	var pending []int
+	for i, result := range b.results {
+		if !result.Done || result.Err != nil {
+			pending = append(pending, i)
+		}
+	}
+	b.mu.Unlock()
+
+	defer func() {
+		b.mu.Lock()
+		b.running = false
+		b.mu.Unlock()
+	}()
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < b.opts.Concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				// the remaining operations are left to be run again
+				if runCtx.Err() != nil {
+					continue
+				}
+				if !b.run(runCtx, i) && b.opts.StopOnError {
+					cancel()
+				}
+			}
+		}()
+	}
+
+loop:
+	for _, i := range pending {
+		select {
+		case indexes <- i:
+		case <-runCtx.Done():
+			break loop
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	batchErr := new(BatchError)
+	for i, result := range b.results {
+		if result.Done && result.Err != nil {
+			batchErr.Failed = append(batchErr.Failed, i)
+			batchErr.Errors = append(batchErr.Errors, result.Err)
+		}
+	}
+	if len(batchErr.Failed) > 0 {
+		return batchErr
+	}
+	return nil
+}
+
+// run runs the operation at the index and records its result. It reports whether the operation succeeded.
+// If the context was canceled while it ran, it's left to be run again.
+func (b *Batch) run(ctx context.Context, i int) bool {
+	b.mu.Lock()
+	op := b.operations[i]
+	b.mu.Unlock()
+
+	var (
+		value interface{}
+		resp  *Response
+		err   error
+	)
+	for retries := 0; ; retries++ {
+		value, resp, err = op(ctx, b.client)
+		wait, ok := rateLimitWait(err)
+		if !ok || retries >= b.opts.RateLimitRetries || sleep(ctx, wait) != nil {
+			break
+		}
+	}
+
+	if err != nil && ctx.Err() != nil {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.results[i] = &BatchResult{Value: value, Response: resp, Err: err, Done: true}
+	return err == nil
+}
+
+// rateLimitWait returns how long to wait before retrying an operation that failed because the rate limit was exceeded.
+func rateLimitWait(err error) (time.Duration, bool) {
+	var submitErr *SubmitRateLimitError
+	if errors.As(err, &submitErr) && submitErr.RetryAfter > 0 {
+		return submitErr.RetryAfter, true
+	}
+
+	var rateErr *RateLimitError
+	if errors.As(err, &rateErr) && !rateErr.Rate.Reset.IsZero() {
+		return time.Until(rateErr.Rate.Reset), true
+	}
+
+	return 0, false
+}
+
+// chunkIDs splits the IDs into chunks of at most size IDs.
+func chunkIDs(ids []string, size int) [][]string {
+	var chunks [][]string
+	for len(ids) > size {
+		chunks = append(chunks, ids[:size])
+		ids = ids[size:]
+	}
+	if len(ids) > 0 {
+		chunks = append(chunks, ids)
+	}
+	return chunks
+}
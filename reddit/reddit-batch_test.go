@@ -0,0 +1,207 @@
+package reddit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatch(t *testing.T) {
+	client, mux := setup(t)
+
+	var (
+		mu          sync.Mutex
+		running     int
+		maxRunning  int
+		approved    []string
+		failingPost = "t3_3"
+	)
+	mux.HandleFunc("/api/approve", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		id := r.Form.Get("id")
+
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+
+		time.Sleep(time.Millisecond * 5)
+
+		mu.Lock()
+		defer mu.Unlock()
+		running--
+
+		if id == failingPost {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		approved = append(approved, id)
+	})
+
+	batch := client.Batch(&BatchOptions{Concurrency: 2})
+	for i := 0; i < 6; i++ {
+		id := "t3_" + strconv.Itoa(i)
+		index := batch.Add(func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+			resp, err := client.Moderation.Approve(ctx, id)
+			return id, resp, err
+		})
+		require.Equal(t, i, index)
+	}
+
+	err := batch.Run(ctx)
+	require.IsType(t, &BatchError{}, err)
+	require.Equal(t, []int{3}, err.(*BatchError).Failed)
+	require.True(t, errors.Is(err.(*BatchError).Errors[0], ErrForbidden))
+	require.Len(t, approved, 5)
+	require.Equal(t, 2, maxRunning)
+
+	results := batch.Results()
+	require.Len(t, results, 6)
+	require.Equal(t, "t3_0", results[0].Value)
+	require.True(t, results[0].Done)
+	require.NoError(t, results[0].Err)
+	require.Equal(t, http.StatusOK, results[0].Response.StatusCode)
+
+	// only the failed operation is run again
+	failingPost = ""
+	require.NoError(t, batch.Run(ctx))
+	require.Len(t, approved, 6)
+	require.Equal(t, "t3_3", approved[5])
+}
+
+func TestBatch_StopOnError(t *testing.T) {
+	client, _ := setup(t)
+
+	var calls int
+	batch := client.Batch(&BatchOptions{Concurrency: 1, StopOnError: true})
+	for i := 0; i < 3; i++ {
+		batch.Add(func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+			calls++
+			return nil, nil, errors.New("test error")
+		})
+	}
+
+	err := batch.Run(ctx)
+	require.EqualError(t, err, "1 operations of the batch failed, the first one with: test error")
+	require.Equal(t, 1, calls)
+
+	results := batch.Results()
+	require.True(t, results[0].Done)
+	require.False(t, results[1].Done)
+	require.False(t, results[2].Done)
+}
+
+func TestBatch_Canceled(t *testing.T) {
+	client, _ := setup(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var calls int
+	batch := client.Batch(&BatchOptions{Concurrency: 1})
+	for i := 0; i < 3; i++ {
+		batch.Add(func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+			calls++
+			if calls == 2 {
+				cancel()
+				return nil, nil, ctx.Err()
+			}
+			return calls, nil, nil
+		})
+	}
+
+	require.Equal(t, context.Canceled, batch.Run(ctx))
+	require.Equal(t, 2, calls)
+
+	results := batch.Results()
+	require.True(t, results[0].Done)
+	require.False(t, results[1].Done)
+	require.False(t, results[2].Done)
+
+	// the batch resumes where it was interrupted
+	require.NoError(t, batch.Run(context.Background()))
+	require.Equal(t, 4, calls)
+	require.Equal(t, 1, batch.Results()[0].Value)
+	require.Equal(t, 3, batch.Results()[1].Value)
+	require.Equal(t, 4, batch.Results()[2].Value)
+}
+
+func TestBatch_RateLimited(t *testing.T) {
+	client, _ := setup(t)
+
+	var calls int
+	batch := client.Batch(nil)
+	batch.Add(func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		calls++
+		if calls == 1 {
+			return nil, nil, &RateLimitError{Rate: Rate{Reset: time.Now().Add(time.Millisecond * 10)}}
+		}
+		return nil, nil, nil
+	})
+
+	require.NoError(t, batch.Run(ctx))
+	require.Equal(t, 2, calls)
+}
+
+func TestBatch_RateLimitRetries(t *testing.T) {
+	client, _ := setup(t)
+
+	var calls int
+	batch := client.Batch(&BatchOptions{RateLimitRetries: 2})
+	batch.Add(func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		calls++
+		return nil, nil, &SubmitRateLimitError{JSONErrorResponse: &JSONErrorResponse{}, RetryAfter: time.Millisecond}
+	})
+
+	err := batch.Run(ctx)
+	require.IsType(t, &BatchError{}, err)
+	require.IsType(t, &SubmitRateLimitError{}, err.(*BatchError).Errors[0])
+	require.Equal(t, 3, calls)
+
+	calls = 0
+	batch = client.Batch(&BatchOptions{RateLimitRetries: -1})
+	batch.Add(func(ctx context.Context, client *Client) (interface{}, *Response, error) {
+		calls++
+		return nil, nil, &RateLimitError{Rate: Rate{Reset: time.Now().Add(time.Millisecond)}}
+	})
+
+	require.Error(t, batch.Run(ctx))
+	require.Equal(t, 1, calls)
+}
+
+func TestBatch_AddGetPosts(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/by_id/", func(w http.ResponseWriter, r *http.Request) {
+		ids := strings.Split(strings.TrimPrefix(r.URL.Path, "/by_id/"), ",")
+		require.LessOrEqual(t, len(ids), 100)
+
+		fmt.Fprint(w, thingListing(ids...))
+	})
+
+	ids := make([]string, 250)
+	for i := range ids {
+		ids[i] = "t3_" + strconv.Itoa(i)
+	}
+
+	batch := client.Batch(nil)
+	require.Equal(t, []int{0, 1, 2}, batch.AddGetPosts(ids...))
+	require.NoError(t, batch.Run(ctx))
+
+	var posts []*Post
+	for _, result := range batch.Results() {
+		posts = append(posts, result.Value.([]*Post)...)
+	}
+	require.Len(t, posts, 250)
+	require.Equal(t, "t3_249", posts[249].FullID)
+}
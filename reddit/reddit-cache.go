@@ -0,0 +1,319 @@
+package reddit
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	headerETag            = "ETag"
+	headerLastModified    = "Last-Modified"
+	headerIfNoneMatch     = "If-None-Match"
+	headerIfModifiedSince = "If-Modified-Since"
+)
+
+// CacheClass is a class of endpoints whose responses are cached for the same amount of time.
+type CacheClass int
+
+const (
+	// CacheClassOther is every endpoint not in another class, e.g. listings.
+	CacheClassOther CacheClass = iota
+	// CacheClassSubreddit is the endpoint used by SubredditService.Get.
+	CacheClassSubreddit
+	// CacheClassRules is the endpoint used by SubredditService.Rules.
+	CacheClassRules
+	// CacheClassModerators is the endpoint used by SubredditService.Moderators.
+	CacheClassModerators
+	// CacheClassWidgets is the endpoint used by WidgetService.Get.
+	CacheClassWidgets
+	// CacheClassEmojis is the endpoint used by EmojiService.Get.
+	CacheClassEmojis
+)
+
+// DefaultCacheTTLs are the TTLs used by WithCache when none are provided.
+// They only cover endpoints returning data that rarely changes.
+var DefaultCacheTTLs = map[CacheClass]time.Duration{
+	CacheClassSubreddit:  time.Minute * 5,
+	CacheClassRules:      time.Hour,
+	CacheClassModerators: time.Minute * 15,
+	CacheClassWidgets:    time.Hour,
+	CacheClassEmojis:     time.Hour,
+}
+
+// CachedResponse is a response to a GET request stored in a Cache.
+type CachedResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	// The response is served from the cache until then. Afterwards, it is revalidated
+	// with a conditional request if it has an ETag or Last-Modified header.
+	Expires time.Time
+}
+
+func (r *CachedResponse) fresh() bool {
+	return time.Now().Before(r.Expires)
+}
+
+func (r *CachedResponse) revalidatable() bool {
+	return r.Header.Get(headerETag) != "" || r.Header.Get(headerLastModified) != ""
+}
+
+// response returns the cached response as a response to the request.
+func (r *CachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        http.StatusText(r.StatusCode),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.Header.Clone(),
+		Body:          ioutil.NopCloser(bytes.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+// Cache stores responses to GET requests (see WithCache).
+// Keys are the path and query of requests, e.g. r/golang/about/rules?raw_json=1.
+// Implementations must be safe for concurrent use. They may be backed by an external store,
+// but shouldn't be shared by clients authenticated as different users, since some responses
+// depend on who requested them.
+type Cache interface {
+	// Get returns the response stored under the key, or nil if there isn't one.
+	// Expired responses should still be returned, so that they can be revalidated.
+	Get(ctx context.Context, key string) (*CachedResponse, error)
+	// Set stores the response under the key, replacing the previous one.
+	Set(ctx context.Context, key string, resp *CachedResponse) error
+	// DeletePrefix removes the responses whose keys start with the prefix.
+	// An empty prefix removes all of them.
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
+// MemoryCache is an in-memory Cache that evicts the least recently used responses
+// once it holds a maximum number of them.
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	order      *list.List
+}
+
+type memoryCacheEntry struct {
+	key  string
+	resp *CachedResponse
+}
+
+// NewMemoryCache returns a new MemoryCache holding up to maxEntries responses.
+// If maxEntries is 0, there is no limit.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// Get returns the response stored under the key, or nil if there isn't one.
+func (c *MemoryCache) Get(_ context.Context, key string) (*CachedResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, nil
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*memoryCacheEntry).resp, nil
+}
+
+// Set stores the response under the key, replacing the previous one.
+func (c *MemoryCache) Set(_ context.Context, key string, resp *CachedResponse) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*memoryCacheEntry).resp = resp
+		c.order.MoveToFront(e)
+		return nil
+	}
+
+	c.entries[key] = c.order.PushFront(&memoryCacheEntry{key: key, resp: resp})
+	if c.maxEntries > 0 && c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*memoryCacheEntry).key)
+	}
+	return nil
+}
+
+// DeletePrefix removes the responses whose keys start with the prefix.
+func (c *MemoryCache) DeletePrefix(_ context.Context, prefix string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, e := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			c.order.Remove(e)
+			delete(c.entries, key)
+		}
+	}
+	return nil
+}
+
+// classifyCachePath returns the class of the endpoint at the path, e.g. r/golang/about/rules.
+func classifyCachePath(path string) CacheClass {
+	segments := strings.Split(path, "/")
+	switch {
+	case len(segments) == 3 && segments[0] == "r" && segments[2] == "about":
+		return CacheClassSubreddit
+	case len(segments) == 4 && segments[0] == "r" && segments[2] == "about" && segments[3] == "rules":
+		return CacheClassRules
+	case len(segments) == 4 && segments[0] == "r" && segments[2] == "about" && segments[3] == "moderators":
+		return CacheClassModerators
+	case len(segments) == 4 && segments[0] == "r" && segments[2] == "api" && segments[3] == "widgets":
+		return CacheClassWidgets
+	case len(segments) == 5 && segments[0] == "api" && segments[1] == "v1" && segments[3] == "emojis" && segments[4] == "all":
+		return CacheClassEmojis
+	}
+	return CacheClassOther
+}
+
+// cachePath returns the path of the request relative to the API, lowercased and without the .json extension.
+func cachePath(req *http.Request) string {
+	path := strings.Trim(req.URL.Path, "/")
+	path = strings.TrimSuffix(path, ".json")
+	return strings.ToLower(path)
+}
+
+// cacheKey returns the key the response to the request is cached under, and for how long.
+// The key is empty if the response isn't cached.
+func (c *Client) cacheKey(req *http.Request) (string, time.Duration) {
+	if c.cache == nil || req.Method != http.MethodGet {
+		return "", 0
+	}
+
+	path := cachePath(req)
+	ttl := c.cacheTTLs[classifyCachePath(path)]
+	if ttl <= 0 {
+		return "", 0
+	}
+
+	return path + "?" + req.URL.Query().Encode(), ttl
+}
+
+// cacheResponse stores the response under the key, and makes its body readable again.
+func (c *Client) cacheResponse(ctx context.Context, key string, ttl time.Duration, resp *http.Response) error {
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return c.cache.Set(ctx, key, &CachedResponse{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+		Body:       body,
+		Expires:    time.Now().Add(ttl),
+	})
+}
+
+// revalidateCachedResponse extends the cached response's expiry, after Reddit reported that it wasn't modified.
+func (c *Client) revalidateCachedResponse(ctx context.Context, key string, ttl time.Duration, cached *CachedResponse) error {
+	revalidated := *cached
+	revalidated.Expires = time.Now().Add(ttl)
+	return c.cache.Set(ctx, key, &revalidated)
+}
+
+// conditionalRequest returns a copy of the request that only gets a response if it was modified since it was cached.
+func conditionalRequest(req *http.Request, cached *CachedResponse) *http.Request {
+	req = req.Clone(req.Context())
+	if etag := cached.Header.Get(headerETag); etag != "" {
+		req.Header.Set(headerIfNoneMatch, etag)
+	}
+	if lastModified := cached.Header.Get(headerLastModified); lastModified != "" {
+		req.Header.Set(headerIfModifiedSince, lastModified)
+	}
+	return req
+}
+
+// invalidateCache removes the cached responses affected by the request, which modifies something on Reddit.
+// If the request targets specific subreddits, e.g. r/golang/api/widget or api/submit with sr=golang,
+// only their responses are removed. Otherwise, all of them are.
+func (c *Client) invalidateCache(ctx context.Context, req *http.Request) error {
+	if c.cache == nil || req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return nil
+	}
+
+	subreddits := requestSubreddits(req)
+	if subreddits == nil {
+		return c.cache.DeletePrefix(ctx, "")
+	}
+
+	for _, sr := range subreddits {
+		if err := c.cache.DeletePrefix(ctx, "r/"+sr+"/"); err != nil {
+			return err
+		}
+		if err := c.cache.DeletePrefix(ctx, "api/v1/"+sr+"/"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// requestSubreddits returns the lowercased names of the subreddits the request targets, or nil if unknown.
+func requestSubreddits(req *http.Request) []string {
+	segments := strings.Split(cachePath(req), "/")
+	switch {
+	case len(segments) > 2 && segments[0] == "r":
+		return []string{segments[1]}
+	case len(segments) > 3 && segments[0] == "api" && segments[1] == "v1" && segments[2] != "me":
+		return []string{segments[2]}
+	}
+
+	form := requestForm(req)
+	for _, field := range []string{"sr", "sr_name", "subreddit", "r"} {
+		v := form.Get(field)
+		// subreddits referenced by their full ID can't be matched to the cached paths
+		if v == "" || strings.HasPrefix(v, kindSubreddit+"_") {
+			continue
+		}
+
+		var subreddits []string
+		for _, sr := range strings.Split(v, ",") {
+			subreddits = append(subreddits, strings.ToLower(strings.TrimSpace(sr)))
+		}
+		return subreddits
+	}
+
+	return nil
+}
+
+// requestForm returns the form-encoded body of the request, if any.
+func requestForm(req *http.Request) url.Values {
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get(headerContentType))
+	if mediaType != mediaTypeForm || req.GetBody == nil {
+		return nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer body.Close()
+
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil
+	}
+
+	form, _ := url.ParseQuery(string(data))
+	return form
+}
@@ -0,0 +1,162 @@
+package reddit
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Cache(t *testing.T) {
+	client, mux := setup(t)
+	require.NoError(t, WithCache(NewMemoryCache(0), nil)(client))
+
+	blob, err := readFileContents("../testdata/subreddit/rules.json")
+	require.NoError(t, err)
+
+	var calls int
+	mux.HandleFunc("/r/testsubreddit/about/rules", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		calls++
+		fmt.Fprint(w, blob)
+	})
+
+	mux.HandleFunc("/r/testsubreddit/api/add_subreddit_rule", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+	})
+
+	rules, resp, err := client.Subreddit.Rules(ctx, "testsubreddit")
+	require.NoError(t, err)
+	require.False(t, resp.Cached)
+
+	cachedRules, resp, err := client.Subreddit.Rules(ctx, "TestSubreddit")
+	require.NoError(t, err)
+	require.True(t, resp.Cached)
+	require.Equal(t, rules, cachedRules)
+	require.Equal(t, 1, calls)
+
+	// adding a rule invalidates the subreddit's cached responses
+	_, err = client.Subreddit.CreateRule(ctx, "testsubreddit", &SubredditRuleCreateRequest{Name: "test", Kind: "all"})
+	require.NoError(t, err)
+
+	_, resp, err = client.Subreddit.Rules(ctx, "testsubreddit")
+	require.NoError(t, err)
+	require.False(t, resp.Cached)
+	require.Equal(t, 2, calls)
+}
+
+func TestClient_Cache_Uncached(t *testing.T) {
+	client, mux := setup(t)
+	require.NoError(t, WithCache(NewMemoryCache(0), map[CacheClass]time.Duration{CacheClassRules: time.Hour})(client))
+
+	var calls int
+	mux.HandleFunc("/r/testsubreddit/about", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"kind": "t5", "data": {}}`)
+	})
+
+	for i := 0; i < 2; i++ {
+		_, resp, err := client.Subreddit.Get(ctx, "testsubreddit")
+		require.NoError(t, err)
+		require.False(t, resp.Cached)
+	}
+	require.Equal(t, 2, calls)
+}
+
+func TestClient_Cache_Revalidate(t *testing.T) {
+	client, mux := setup(t)
+
+	cache := NewMemoryCache(0)
+	require.NoError(t, WithCache(cache, nil)(client))
+
+	var conditions []string
+	mux.HandleFunc("/r/testsubreddit/about", func(w http.ResponseWriter, r *http.Request) {
+		conditions = append(conditions, r.Header.Get(headerIfNoneMatch))
+		if r.Header.Get(headerIfNoneMatch) == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set(headerETag, `"v1"`)
+		fmt.Fprint(w, `{"kind": "t5", "data": {"display_name": "testsubreddit"}}`)
+	})
+
+	_, _, err := client.Subreddit.Get(ctx, "testsubreddit")
+	require.NoError(t, err)
+
+	// expire the cached response
+	key := "r/testsubreddit/about?"
+	cached, err := cache.Get(ctx, key)
+	require.NoError(t, err)
+	require.NotNil(t, cached)
+	cached.Expires = time.Now().Add(-time.Second)
+
+	subreddit, resp, err := client.Subreddit.Get(ctx, "testsubreddit")
+	require.NoError(t, err)
+	require.False(t, resp.Cached)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "testsubreddit", subreddit.Name)
+	require.Equal(t, []string{"", `"v1"`}, conditions)
+
+	// the revalidated response is fresh again
+	_, resp, err = client.Subreddit.Get(ctx, "testsubreddit")
+	require.NoError(t, err)
+	require.True(t, resp.Cached)
+	require.Len(t, conditions, 2)
+}
+
+func TestMemoryCache(t *testing.T) {
+	cache := NewMemoryCache(2)
+
+	for _, key := range []string{"r/a/about?", "r/b/about?", "r/c/about?"} {
+		require.NoError(t, cache.Set(ctx, key, &CachedResponse{StatusCode: http.StatusOK}))
+	}
+
+	// the least recently used response was evicted
+	resp, err := cache.Get(ctx, "r/a/about?")
+	require.NoError(t, err)
+	require.Nil(t, resp)
+
+	resp, err = cache.Get(ctx, "r/b/about?")
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+
+	require.NoError(t, cache.DeletePrefix(ctx, "r/b/"))
+	resp, err = cache.Get(ctx, "r/b/about?")
+	require.NoError(t, err)
+	require.Nil(t, resp)
+
+	require.NoError(t, cache.DeletePrefix(ctx, ""))
+	resp, err = cache.Get(ctx, "r/c/about?")
+	require.NoError(t, err)
+	require.Nil(t, resp)
+}
+
+func TestRequestSubreddits(t *testing.T) {
+	client, _ := setup(t)
+
+	tests := []struct {
+		path     string
+		form     url.Values
+		expected []string
+	}{
+		{"r/Golang/api/widget", nil, []string{"golang"}},
+		{"api/v1/golang/emoji.json", nil, []string{"golang"}},
+		{"api/submit", url.Values{"sr": {"golang"}}, []string{"golang"}},
+		{"api/site_admin", url.Values{"sr": {"t5_abc"}}, nil},
+		{"api/vote", url.Values{"id": {"t3_abc"}}, nil},
+	}
+
+	for _, test := range tests {
+		req, err := client.NewRequest(http.MethodPost, test.path, test.form)
+		require.NoError(t, err)
+		require.Equal(t, test.expected, requestSubreddits(req), test.path)
+	}
+}
+
+func TestWithCache_Error(t *testing.T) {
+	_, err := NewClient(Credentials{}, WithCache(nil, nil))
+	require.EqualError(t, err, "Cache: cannot be nil")
+}
@@ -0,0 +1,185 @@
+package reddit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type accountContextKey struct{}
+
+// WithAccount returns a copy of the context that makes a ClientPool use the account with the name.
+func WithAccount(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, accountContextKey{}, name)
+}
+
+// AccountFromContext returns the name of the account set in the context via WithAccount, if any.
+func AccountFromContext(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(accountContextKey{}).(string)
+	return name, ok
+}
+
+// ClientPool holds clients for several named accounts, e.g. multiple bot accounts.
+// Each account has its own client, with its own token source and rate limit.
+// The account used for a call can be chosen through the context (see WithAccount),
+// otherwise the pool rotates between the accounts that aren't currently rate limited.
+// It is safe for concurrent use.
+type ClientPool struct {
+	opts []Opt
+
+	mu      sync.Mutex
+	names   []string
+	clients map[string]*Client
+	next    int
+}
+
+// NewClientPool returns a new, empty ClientPool.
+// The options are applied to the client of every account added with Add.
+// Avoid options that would make the accounts share state meant for a single account,
+// such as WithHTTPClient with the same *http.Client, or WithRateBudget with the same budget.
+func NewClientPool(opts ...Opt) *ClientPool {
+	return &ClientPool{
+		opts:    opts,
+		clients: make(map[string]*Client),
+	}
+}
+
+// Add creates a client for the account with the credentials and adds it to the pool under the name.
+// The options are applied after the ones the pool was created with.
+func (p *ClientPool) Add(name string, credentials Credentials, opts ...Opt) error {
+	allOpts := make([]Opt, 0, len(p.opts)+len(opts))
+	allOpts = append(allOpts, p.opts...)
+	allOpts = append(allOpts, opts...)
+
+	client, err := NewClient(credentials, allOpts...)
+	if err != nil {
+		return err
+	}
+
+	return p.AddClient(name, client)
+}
+
+// AddClient adds an existing client to the pool under the name, e.g. one created with NewClientWithToken.
+func (p *ClientPool) AddClient(name string, client *Client) error {
+	if name == "" {
+		return errors.New("account name: cannot be empty")
+	}
+	if client == nil {
+		return errors.New("*Client: cannot be nil")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.clients[name]; ok {
+		return fmt.Errorf("account %q already exists", name)
+	}
+
+	p.names = append(p.names, name)
+	p.clients[name] = client
+	return nil
+}
+
+// Accounts returns the names of the accounts in the pool, in the order they were added.
+func (p *ClientPool) Accounts() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.names...)
+}
+
+// Account returns the client of the account with the name.
+func (p *ClientPool) Account(name string) (*Client, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	client, ok := p.clients[name]
+	return client, ok
+}
+
+// Client returns the client to use for a call made with the context.
+// If the context names an account (see WithAccount), that account's client is returned.
+// Otherwise, it returns the next account's client in round-robin order, skipping the accounts
+// that are currently rate limited. If all of them are, the one whose limit resets first is returned.
+func (p *ClientPool) Client(ctx context.Context) (*Client, error) {
+	if name, ok := AccountFromContext(ctx); ok {
+		client, ok := p.Account(name)
+		if !ok {
+			return nil, fmt.Errorf("account %q does not exist", name)
+		}
+		return client, nil
+	}
+
+	client, _, err := p.pick(ctx, nil)
+	return client, err
+}
+
+// Do calls fn with the client to use for the context (see Client).
+// If no account was set in the context and fn fails with an error matching ErrRateLimited, fn is called
+// again with the next account that isn't rate limited, until every account has been tried.
+func (p *ClientPool) Do(ctx context.Context, fn func(context.Context, *Client) error) error {
+	if _, ok := AccountFromContext(ctx); ok {
+		client, err := p.Client(ctx)
+		if err != nil {
+			return err
+		}
+		return fn(ctx, client)
+	}
+
+	tried := make(map[string]bool)
+	for {
+		client, name, err := p.pick(ctx, tried)
+		if err != nil {
+			return err
+		}
+		tried[name] = true
+
+		err = fn(WithAccount(ctx, name), client)
+		if !errors.Is(err, ErrRateLimited) || len(tried) == len(p.Accounts()) {
+			return err
+		}
+	}
+}
+
+// pick returns the next account in round-robin order that isn't rate limited, ignoring the ones in skip.
+// If all of them are rate limited, it returns the one whose limit resets first.
+func (p *ClientPool) pick(ctx context.Context, skip map[string]bool) (*Client, string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.names) == 0 {
+		return nil, "", errors.New("client pool has no accounts")
+	}
+
+	now := time.Now()
+	var fallback string
+	var fallbackReset time.Time
+
+	for i := 0; i < len(p.names); i++ {
+		name := p.names[(p.next+i)%len(p.names)]
+		if skip[name] {
+			continue
+		}
+
+		client := p.clients[name]
+		rate, err := client.getRate(ctx)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if rate.Remaining > 0 || rate.Reset.IsZero() || !now.Before(rate.Reset) {
+			p.next = (p.next + i + 1) % len(p.names)
+			return client, name, nil
+		}
+
+		if fallback == "" || rate.Reset.Before(fallbackReset) {
+			fallback = name
+			fallbackReset = rate.Reset
+		}
+	}
+
+	if fallback == "" {
+		return nil, "", errors.New("client pool has no accounts left to try")
+	}
+	return p.clients[fallback], fallback, nil
+}
@@ -0,0 +1,161 @@
+package reddit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountFromContext(t *testing.T) {
+	_, ok := AccountFromContext(ctx)
+	require.False(t, ok)
+
+	name, ok := AccountFromContext(WithAccount(ctx, "bot1"))
+	require.True(t, ok)
+	require.Equal(t, "bot1", name)
+}
+
+func TestClientPool_Add(t *testing.T) {
+	pool := NewClientPool()
+
+	_, err := pool.Client(ctx)
+	require.EqualError(t, err, "client pool has no accounts")
+
+	require.NoError(t, pool.Add("bot1", Credentials{ID: "id1"}))
+	require.NoError(t, pool.Add("bot2", Credentials{ID: "id2"}, WithUserAgent("agent2")))
+	require.EqualError(t, pool.Add("bot1", Credentials{}), `account "bot1" already exists`)
+	require.EqualError(t, pool.Add("", Credentials{}), "account name: cannot be empty")
+	require.EqualError(t, pool.AddClient("bot3", nil), "*Client: cannot be nil")
+	require.Error(t, pool.Add("bot3", Credentials{}, WithBaseURL(":")))
+
+	require.Equal(t, []string{"bot1", "bot2"}, pool.Accounts())
+
+	client, ok := pool.Account("bot2")
+	require.True(t, ok)
+	require.Equal(t, "id2", client.ID)
+	require.Equal(t, "agent2", client.UserAgent())
+
+	_, ok = pool.Account("bot3")
+	require.False(t, ok)
+}
+
+func TestClientPool_Client(t *testing.T) {
+	pool := NewClientPool()
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, pool.Add(fmt.Sprintf("bot%d", i), Credentials{ID: fmt.Sprintf("id%d", i)}))
+	}
+
+	var ids []string
+	for i := 0; i < 4; i++ {
+		client, err := pool.Client(ctx)
+		require.NoError(t, err)
+		ids = append(ids, client.ID)
+	}
+	require.Equal(t, []string{"id1", "id2", "id3", "id1"}, ids)
+
+	client, err := pool.Client(WithAccount(ctx, "bot3"))
+	require.NoError(t, err)
+	require.Equal(t, "id3", client.ID)
+
+	_, err = pool.Client(WithAccount(ctx, "bot4"))
+	require.EqualError(t, err, `account "bot4" does not exist`)
+
+	// rate limited accounts are skipped
+	bot2, _ := pool.Account("bot2")
+	require.NoError(t, bot2.setRate(ctx, Rate{Remaining: 0, Reset: time.Now().Add(time.Minute)}))
+	bot3, _ := pool.Account("bot3")
+	require.NoError(t, bot3.setRate(ctx, Rate{Remaining: 0, Reset: time.Now().Add(time.Hour)}))
+
+	client, err = pool.Client(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "id1", client.ID)
+
+	// unless they all are, in which case the one that resets first is used
+	bot1, _ := pool.Account("bot1")
+	require.NoError(t, bot1.setRate(ctx, Rate{Remaining: 0, Reset: time.Now().Add(2 * time.Minute)}))
+
+	client, err = pool.Client(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "id2", client.ID)
+}
+
+func TestClientPool_Do(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/api/v1/access_token2", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+
+		w.Header().Add(headerContentType, mediaTypeJSON)
+		fmt.Fprintf(w, `{
+			"access_token": "%s",
+			"token_type": "bearer",
+			"expires_in": 3600,
+			"scope": "*"
+		}`, r.PostForm.Get("username"))
+	})
+
+	var tokens []string
+	mux.HandleFunc("/api/v1/test", func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get("Authorization")
+		tokens = append(tokens, token)
+
+		// the first account is out of requests
+		remaining := 100
+		if token == "Bearer user1" {
+			remaining = 0
+		}
+		w.Header().Set(headerRateLimitRemaining, strconv.Itoa(remaining))
+		w.Header().Set(headerRateLimitUsed, "600")
+		w.Header().Set(headerRateLimitReset, "60")
+	})
+
+	pool := NewClientPool(
+		WithBaseURL(client.BaseURL.String()),
+		WithTokenURL(client.BaseURL.String()+"/api/v1/access_token2"),
+	)
+	require.NoError(t, pool.Add("bot1", Credentials{"id1", "secret1", "user1", "password1"}))
+	require.NoError(t, pool.Add("bot2", Credentials{"id1", "secret1", "user2", "password2"}))
+
+	var accounts []string
+	call := func(ctx context.Context, client *Client) error {
+		name, _ := AccountFromContext(ctx)
+		accounts = append(accounts, name)
+
+		req, err := client.NewRequest(http.MethodGet, "api/v1/test", nil)
+		require.NoError(t, err)
+
+		_, err = client.Do(ctx, req, nil)
+		return err
+	}
+
+	// the first account runs out of requests, so the call is made again with the next one
+	require.NoError(t, pool.Do(ctx, call))
+	// the first account is still rate limited, so it gets skipped
+	require.NoError(t, pool.Do(ctx, call))
+	require.Equal(t, []string{"bot1", "bot2", "bot2"}, accounts)
+	require.Equal(t, []string{"Bearer user1", "Bearer user2", "Bearer user2"}, tokens)
+
+	// when the account is chosen explicitly, the rate limit error is returned as is
+	err := pool.Do(WithAccount(ctx, "bot1"), call)
+	require.IsType(t, &RateLimitError{}, err)
+	require.Len(t, tokens, 3)
+
+	// when every account is rate limited, each one is tried once, starting with the one that resets first
+	accounts = nil
+	bot2, _ := pool.Account("bot2")
+	require.NoError(t, bot2.setRate(ctx, Rate{Remaining: 0, Reset: time.Now().Add(time.Hour)}))
+
+	err = pool.Do(ctx, func(ctx context.Context, client *Client) error {
+		name, _ := AccountFromContext(ctx)
+		accounts = append(accounts, name)
+		return &RateLimitError{Response: &http.Response{Request: &http.Request{URL: &url.URL{}}}}
+	})
+	require.IsType(t, &RateLimitError{}, err)
+	require.Equal(t, []string{"bot1", "bot2"}, accounts)
+}
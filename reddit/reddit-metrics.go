@@ -0,0 +1,248 @@
+package reddit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultLatencyBuckets are the upper bounds, in seconds, of the buckets of the request latency histogram.
+var DefaultLatencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// Metrics is an Observer that collects metrics about the requests made by a client (see WithObserver),
+// and exposes them in the Prometheus text format as an http.Handler:
+//
+//	metrics := reddit.NewMetrics(map[string]string{"bot": "modbot"})
+//	client, _ := reddit.NewClient(credentials, reddit.WithObserver(metrics))
+//	http.Handle("/metrics", metrics)
+//
+// The following metrics are collected:
+//
+//	reddit_requests_total: counter of requests, by operation (e.g. Post.SubmitText) and status code.
+//	  Responses served from the client's cache have the status "cached", and requests that got
+//	  no response (e.g. because of a network error) have the status "error".
+//	reddit_request_duration_seconds: histogram of the latency of requests, by operation.
+//	reddit_rate_limited_total: counter of requests that failed because the rate limit was exceeded, by operation.
+//	reddit_retries_total: counter of retried requests, by operation.
+//	reddit_rate_limit_remaining: gauge of the requests remaining in the current rate limit window.
+//	reddit_rate_limit_used: gauge of the requests used in the current rate limit window.
+//	reddit_rate_limit_reset_timestamp_seconds: gauge of when the current rate limit window resets.
+//
+// A Metrics should only observe a single client, or clients sharing the same rate limit (see WithRateBudget).
+// Use labels to tell apart the metrics of different ones.
+type Metrics struct {
+	labels  string
+	buckets []float64
+
+	mu          sync.Mutex
+	requests    map[metricKey]int64
+	latencies   map[string]*histogram
+	rateLimited map[string]int64
+	retries     map[string]int64
+	rate        Rate
+}
+
+type metricKey struct {
+	operation string
+	status    string
+}
+
+type histogram struct {
+	// non-cumulative counts of the observations in each bucket, the last one being +Inf
+	counts []int64
+	sum    float64
+	count  int64
+}
+
+// NewMetrics returns a new Metrics. The labels are added to every metric, e.g. to identify the bot it belongs to.
+func NewMetrics(labels map[string]string) *Metrics {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = labelPair(name, labels[name])
+	}
+
+	return &Metrics{
+		labels:      strings.Join(pairs, ","),
+		buckets:     DefaultLatencyBuckets,
+		requests:    make(map[metricKey]int64),
+		latencies:   make(map[string]*histogram),
+		rateLimited: make(map[string]int64),
+		retries:     make(map[string]int64),
+	}
+}
+
+// OnRequestStart does nothing, since metrics are collected once requests end.
+func (m *Metrics) OnRequestStart(ctx context.Context, _ *RequestStartEvent) context.Context {
+	return ctx
+}
+
+// OnRequestEnd collects the metrics of the request.
+func (m *Metrics) OnRequestEnd(_ context.Context, event *RequestEndEvent) {
+	operation := metricOperation(event.Operation)
+
+	status := strconv.Itoa(event.StatusCode)
+	switch {
+	case event.Cached:
+		status = "cached"
+	case event.StatusCode == 0:
+		status = "error"
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[metricKey{operation, status}]++
+
+	h, ok := m.latencies[operation]
+	if !ok {
+		h = &histogram{counts: make([]int64, len(m.buckets)+1)}
+		m.latencies[operation] = h
+	}
+	seconds := event.Duration.Seconds()
+	h.counts[sort.SearchFloat64s(m.buckets, seconds)]++
+	h.sum += seconds
+	h.count++
+
+	if errors.Is(event.Err, ErrRateLimited) {
+		m.rateLimited[operation]++
+	}
+
+	if !event.Cached && !event.Rate.Reset.IsZero() {
+		m.rate = MergeRate(m.rate, event.Rate)
+	}
+}
+
+// OnRetry counts the retry.
+func (m *Metrics) OnRetry(_ context.Context, event *RetryEvent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.retries[metricOperation(event.Operation)]++
+}
+
+// metricOperation returns the operation label of a request. Requests sent directly with Client.Do have none.
+func metricOperation(operation string) string {
+	if operation == "" {
+		return "other"
+	}
+	return operation
+}
+
+// ServeHTTP writes the metrics in the Prometheus text format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set(headerContentType, "text/plain; version=0.0.4; charset=utf-8")
+	m.WriteTo(w)
+}
+
+// WriteTo writes the metrics to w in the Prometheus text format.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	buf := new(bytes.Buffer)
+
+	m.mu.Lock()
+
+	writeMetricHeader(buf, "reddit_requests_total", "counter", "Requests made to the Reddit API, by operation and status code.")
+	keys := make([]metricKey, 0, len(m.requests))
+	for key := range m.requests {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].operation != keys[j].operation {
+			return keys[i].operation < keys[j].operation
+		}
+		return keys[i].status < keys[j].status
+	})
+	for _, key := range keys {
+		m.writeSample(buf, "reddit_requests_total", float64(m.requests[key]), labelPair("operation", key.operation), labelPair("status", key.status))
+	}
+
+	writeMetricHeader(buf, "reddit_request_duration_seconds", "histogram", "Latency of requests made to the Reddit API, by operation.")
+	for _, operation := range sortedKeys(m.latencies) {
+		h := m.latencies[operation]
+		var cumulative int64
+		for i, count := range h.counts {
+			cumulative += count
+			le := "+Inf"
+			if i < len(m.buckets) {
+				le = strconv.FormatFloat(m.buckets[i], 'g', -1, 64)
+			}
+			m.writeSample(buf, "reddit_request_duration_seconds_bucket", float64(cumulative), labelPair("operation", operation), labelPair("le", le))
+		}
+		m.writeSample(buf, "reddit_request_duration_seconds_sum", h.sum, labelPair("operation", operation))
+		m.writeSample(buf, "reddit_request_duration_seconds_count", float64(h.count), labelPair("operation", operation))
+	}
+
+	writeMetricHeader(buf, "reddit_rate_limited_total", "counter", "Requests that failed because the rate limit was exceeded, by operation.")
+	for _, operation := range sortedCounterKeys(m.rateLimited) {
+		m.writeSample(buf, "reddit_rate_limited_total", float64(m.rateLimited[operation]), labelPair("operation", operation))
+	}
+
+	writeMetricHeader(buf, "reddit_retries_total", "counter", "Retried requests, by operation.")
+	for _, operation := range sortedCounterKeys(m.retries) {
+		m.writeSample(buf, "reddit_retries_total", float64(m.retries[operation]), labelPair("operation", operation))
+	}
+
+	if !m.rate.Reset.IsZero() {
+		writeMetricHeader(buf, "reddit_rate_limit_remaining", "gauge", "Requests remaining in the current rate limit window.")
+		m.writeSample(buf, "reddit_rate_limit_remaining", float64(m.rate.Remaining))
+		writeMetricHeader(buf, "reddit_rate_limit_used", "gauge", "Requests used in the current rate limit window.")
+		m.writeSample(buf, "reddit_rate_limit_used", float64(m.rate.Used))
+		writeMetricHeader(buf, "reddit_rate_limit_reset_timestamp_seconds", "gauge", "Unix time at which the current rate limit window resets.")
+		m.writeSample(buf, "reddit_rate_limit_reset_timestamp_seconds", float64(m.rate.Reset.Unix()))
+	}
+
+	m.mu.Unlock()
+
+	return buf.WriteTo(w)
+}
+
+func writeMetricHeader(buf *bytes.Buffer, name, typ, help string) {
+	fmt.Fprintf(buf, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+func (m *Metrics) writeSample(buf *bytes.Buffer, name string, value float64, labels ...string) {
+	if m.labels != "" {
+		labels = append([]string{m.labels}, labels...)
+	}
+
+	buf.WriteString(name)
+	if len(labels) > 0 {
+		buf.WriteString("{" + strings.Join(labels, ",") + "}")
+	}
+	buf.WriteString(" " + strconv.FormatFloat(value, 'g', -1, 64) + "\n")
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func labelPair(name, value string) string {
+	return name + `="` + labelValueReplacer.Replace(value) + `"`
+}
+
+func sortedKeys(m map[string]*histogram) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedCounterKeys(m map[string]int64) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
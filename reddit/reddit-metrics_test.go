@@ -0,0 +1,87 @@
+package reddit
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	client, mux := setup(t)
+
+	metrics := NewMetrics(map[string]string{"bot": `mod"bot`})
+	require.NoError(t, WithObserver(metrics)(client))
+	require.NoError(t, WithRetryPolicy(testRetryPolicy)(client))
+
+	var counter int
+	mux.HandleFunc("/r/golang/about", func(w http.ResponseWriter, r *http.Request) {
+		counter++
+		if counter == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set(headerRateLimitRemaining, "590")
+		w.Header().Set(headerRateLimitUsed, "10")
+		w.Header().Set(headerRateLimitReset, "300")
+		fmt.Fprint(w, `{"kind": "t5", "data": {}}`)
+	})
+
+	mux.HandleFunc("/api/submit", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"json": {"errors": [["RATELIMIT", "you are doing that too much. try again in 5 minutes.", "ratelimit"]]}}`)
+	})
+
+	_, _, err := client.Subreddit.Get(ctx, "golang")
+	require.NoError(t, err)
+
+	_, _, err = client.Post.SubmitText(ctx, SubmitTextRequest{Subreddit: "golang", Title: "test"})
+	require.True(t, errors.Is(err, ErrRateLimited))
+
+	w := httptest.NewRecorder()
+	metrics.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, "text/plain; version=0.0.4; charset=utf-8", w.Header().Get(headerContentType))
+
+	body := w.Body.String()
+	for _, line := range []string{
+		"# TYPE reddit_requests_total counter",
+		`reddit_requests_total{bot="mod\"bot",operation="Post.SubmitText",status="200"} 1`,
+		`reddit_requests_total{bot="mod\"bot",operation="Subreddit.Get",status="200"} 1`,
+		`reddit_requests_total{bot="mod\"bot",operation="Subreddit.Get",status="502"} 1`,
+		"# TYPE reddit_request_duration_seconds histogram",
+		`reddit_request_duration_seconds_bucket{bot="mod\"bot",operation="Subreddit.Get",le="+Inf"} 2`,
+		`reddit_request_duration_seconds_count{bot="mod\"bot",operation="Subreddit.Get"} 2`,
+		`reddit_rate_limited_total{bot="mod\"bot",operation="Post.SubmitText"} 1`,
+		`reddit_retries_total{bot="mod\"bot",operation="Subreddit.Get"} 1`,
+		`reddit_rate_limit_remaining{bot="mod\"bot"} 590`,
+		`reddit_rate_limit_used{bot="mod\"bot"} 10`,
+	} {
+		require.Contains(t, body, line+"\n")
+	}
+}
+
+func TestMetrics_Histogram(t *testing.T) {
+	metrics := NewMetrics(nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	for _, d := range []time.Duration{time.Millisecond * 10, time.Millisecond * 100, time.Second * 60} {
+		metrics.OnRequestEnd(ctx, &RequestEndEvent{Request: req, Duration: d})
+	}
+
+	buf := new(bytes.Buffer)
+	_, err := metrics.WriteTo(buf)
+	require.NoError(t, err)
+
+	body := buf.String()
+	require.Contains(t, body, `reddit_requests_total{operation="other",status="error"} 3`+"\n")
+	require.Contains(t, body, `reddit_request_duration_seconds_bucket{operation="other",le="0.05"} 1`+"\n")
+	require.Contains(t, body, `reddit_request_duration_seconds_bucket{operation="other",le="0.1"} 2`+"\n")
+	require.Contains(t, body, `reddit_request_duration_seconds_bucket{operation="other",le="30"} 2`+"\n")
+	require.Contains(t, body, `reddit_request_duration_seconds_bucket{operation="other",le="+Inf"} 3`+"\n")
+	require.Contains(t, body, `reddit_request_duration_seconds_sum{operation="other"} 60.11`+"\n")
+	require.NotContains(t, body, "reddit_rate_limit_remaining")
+}
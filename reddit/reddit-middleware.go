@@ -0,0 +1,35 @@
+package reddit
+
+import (
+	"context"
+	"net/http"
+)
+
+// Handler sends an API request and returns the API response, decoding it into v.
+// It has the same contract as Client.Do.
+type Handler func(ctx context.Context, req *http.Request, v interface{}) (*Response, error)
+
+// Middleware wraps a Handler to add behaviour before and/or after it, such as logging, metrics, caching,
+// request signing or fault injection. A middleware may also skip calling the next handler altogether.
+//
+// The innermost handler is the client's own request pipeline: the rate limit check, the HTTP transport,
+// the retries (if a retry policy is set), CheckResponse, and decoding the response.
+type Middleware func(next Handler) Handler
+
+// handler returns the client's request pipeline wrapped with its middleware.
+// The first middleware registered is the outermost one.
+func (c *Client) handler() Handler {
+	h := c.send
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		h = c.middleware[i](h)
+	}
+	return h
+}
+
+// send sends the request, retrying it if the client has a retry policy.
+func (c *Client) send(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+	if c.retryPolicy != nil {
+		return c.doWithRetry(ctx, req, v)
+	}
+	return c.observe(ctx, req, v, 1)
+}
@@ -0,0 +1,89 @@
+package reddit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Do_Middleware(t *testing.T) {
+	client, mux := setup(t)
+
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+				calls = append(calls, name+" before")
+				resp, err := next(ctx, req, v)
+				calls = append(calls, name+" after")
+				return resp, err
+			}
+		}
+	}
+
+	sign := func(next Handler) Handler {
+		return func(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+			req.Header.Set("X-Signature", "signed")
+			return next(ctx, req, v)
+		}
+	}
+
+	require.NoError(t, WithMiddleware(record("first"), record("second"))(client))
+	require.NoError(t, WithMiddleware(sign)(client))
+
+	mux.HandleFunc("/api/v1/test", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "signed", r.Header.Get("X-Signature"))
+		calls = append(calls, "server")
+		fmt.Fprint(w, `{"name": "test"}`)
+	})
+
+	req, err := client.NewRequest(http.MethodGet, "api/v1/test", nil)
+	require.NoError(t, err)
+
+	root := new(struct {
+		Name string `json:"name"`
+	})
+	resp, err := client.Do(ctx, req, root)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "test", root.Name)
+	require.Equal(t, []string{"first before", "second before", "server", "second after", "first after"}, calls)
+}
+
+func TestClient_Do_Middleware_ShortCircuit(t *testing.T) {
+	client, mux := setup(t)
+
+	errFault := errors.New("injected fault")
+	fault := func(next Handler) Handler {
+		return func(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+			return nil, errFault
+		}
+	}
+	require.NoError(t, WithMiddleware(fault)(client))
+
+	var counter int
+	mux.HandleFunc("/api/v1/test", func(w http.ResponseWriter, r *http.Request) {
+		counter++
+	})
+
+	req, err := client.NewRequest(http.MethodGet, "api/v1/test", nil)
+	require.NoError(t, err)
+
+	_, err = client.Do(ctx, req, nil)
+	require.Equal(t, errFault, err)
+	require.Equal(t, 0, counter)
+}
+
+func TestWithMiddleware(t *testing.T) {
+	_, err := NewClient(Credentials{}, WithMiddleware(nil))
+	require.EqualError(t, err, "Middleware: cannot be nil")
+
+	c, err := NewClient(Credentials{}, WithMiddleware(), WithMiddleware(func(next Handler) Handler { return next }))
+	require.NoError(t, err)
+	require.Len(t, c.middleware, 1)
+}
@@ -13,6 +13,8 @@ Docs:
 	  use the app. Only has access to your account.
 
 Best option for a client like this is to use the script option.
+Web and installed apps can act on behalf of other users via the authorization code flow
+instead: see AuthorizeURL, ExchangeCode and NewClientWithToken.
 
 2. After creating the app, you will get a client id and client secret.
 
@@ -35,9 +37,20 @@ package reddit
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const (
+	grantTypeInstalledClient = "https://oauth.reddit.com/grants/installed_client"
+
+	// Reddit's recommended device ID for installed apps that don't want to track their users.
+	defaultDeviceID = "DO_NOT_TRACK_THIS_DEVICE"
 )
 
 type oauthTokenSource struct {
@@ -50,28 +63,201 @@ func (s *oauthTokenSource) Token() (*oauth2.Token, error) {
 	return s.config.PasswordCredentialsToken(s.ctx, s.username, s.password)
 }
 
-func oauthTransport(client *Client) http.RoundTripper {
-	httpClient := &http.Client{Transport: client.client.Transport}
-	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, httpClient)
-
-	config := &oauth2.Config{
+// oauthConfig returns the OAuth2 configuration of the client's app.
+func oauthConfig(client *Client) *oauth2.Config {
+	return &oauth2.Config{
 		ClientID:     client.ID,
 		ClientSecret: client.Secret,
 		Endpoint: oauth2.Endpoint{
+			AuthURL:   defaultAuthURL,
 			TokenURL:  client.TokenURL.String(),
 			AuthStyle: oauth2.AuthStyleInHeader,
 		},
 	}
+}
 
-	tokenSource := oauth2.ReuseTokenSource(nil, &oauthTokenSource{
-		ctx:      ctx,
-		config:   config,
-		username: client.Username,
-		password: client.Password,
-	})
+// oauthContext returns a context that makes the oauth2 package
+// use the client's transport when requesting access tokens.
+func oauthContext(ctx context.Context, client *Client) context.Context {
+	httpClient := &http.Client{Transport: client.client.Transport}
+	return context.WithValue(ctx, oauth2.HTTPClient, httpClient)
+}
+
+// applicationTokenSource returns a token source for application-only OAuth, which
+// authenticates as the app itself rather than as one of its users.
+func applicationTokenSource(ctx context.Context, client *Client) oauth2.TokenSource {
+	config := &clientcredentials.Config{
+		ClientID:     client.ID,
+		ClientSecret: client.Secret,
+		TokenURL:     client.TokenURL.String(),
+		AuthStyle:    oauth2.AuthStyleInHeader,
+	}
+
+	// installed apps can't keep a secret, so they identify the device instead
+	if client.deviceID != "" {
+		config.EndpointParams = url.Values{
+			"grant_type": {grantTypeInstalledClient},
+			"device_id":  {client.deviceID},
+		}
+	}
+
+	return config.TokenSource(ctx)
+}
+
+func oauthTransport(client *Client) (*oauth2.Transport, error) {
+	ctx := oauthContext(context.Background(), client)
+	config := oauthConfig(client)
+
+	var stored *oauth2.Token
+	if client.tokenStore != nil {
+		var err error
+		if stored, err = client.tokenStore.Load(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	var tokenSource oauth2.TokenSource
+	switch {
+	case client.tokenSource != nil:
+		tokenSource = client.tokenSource
+	case client.token != nil:
+		// the stored token is more recent than the one the client was initialized with
+		token := client.token
+		if stored != nil {
+			token = stored
+		}
+		// refreshes the token using its refresh token once it expires
+		tokenSource = config.TokenSource(ctx, token)
+	case client.applicationOnly:
+		tokenSource = applicationTokenSource(ctx, client)
+	default:
+		tokenSource = oauth2.ReuseTokenSource(nil, &oauthTokenSource{
+			ctx:      ctx,
+			config:   config,
+			username: client.Username,
+			password: client.Password,
+		})
+	}
+
+	if client.tokenStore != nil {
+		tokenSource = &storingTokenSource{
+			ctx:   ctx,
+			store: client.tokenStore,
+			base:  tokenSource,
+			token: stored,
+		}
+	}
+
+	tokenSource = &scopeRecordingTokenSource{
+		client: client,
+		base:   tokenSource,
+	}
 
 	return &oauth2.Transport{
 		Source: tokenSource,
 		Base:   client.client.Transport,
+	}, nil
+}
+
+// AuthorizeURL returns the URL of the page where users grant your app
+// permission to access their account with the provided scopes.
+// Once they do so (or decline), Reddit redirects them to the redirect URI of your app,
+// with the state and an authorization code (or an error) in the URL's query.
+// The code can then be exchanged for an access token with ExchangeCode.
+//
+// If permanent is true, the access token will come with a refresh token, so that your app
+// can keep acting on behalf of the user after the access token expires (after 1 hour).
+func AuthorizeURL(clientID, redirectURI, state string, permanent bool, scopes ...string) string {
+	config := &oauth2.Config{
+		ClientID:    clientID,
+		RedirectURL: redirectURI,
+		Scopes:      scopes,
+		Endpoint:    oauth2.Endpoint{AuthURL: defaultAuthURL},
+	}
+
+	duration := "temporary"
+	if permanent {
+		duration = "permanent"
+	}
+
+	return config.AuthCodeURL(state, oauth2.SetAuthURLParam("duration", duration))
+}
+
+// ExchangeCode exchanges the authorization code Reddit sent to your app's redirect URI (see AuthorizeURL)
+// for an access token. The redirect URI must be the same as the one used to get the code.
+// Only the ID and Secret of the credentials are used. Installed apps don't have a secret, so theirs is left empty.
+// Options such as WithHTTPClient, WithUserAgent and WithTokenURL are applied to the request.
+func ExchangeCode(ctx context.Context, credentials Credentials, redirectURI, code string, opts ...Opt) (*oauth2.Token, error) {
+	client := newClient()
+	client.ID = credentials.ID
+	client.Secret = credentials.Secret
+
+	for _, opt := range opts {
+		if err := opt(client); err != nil {
+			return nil, err
+		}
+	}
+
+	client.client.Transport = &userAgentTransport{
+		userAgent: client.UserAgent(),
+		Base:      client.client.Transport,
+	}
+
+	config := oauthConfig(client)
+	config.RedirectURL = redirectURI
+
+	return config.Exchange(oauthContext(ctx, client), code)
+}
+
+// RevokeToken revokes the client's current token, logging it out of Reddit, and deletes it from the
+// client's token store (if it has one). If the token has a refresh token, that is revoked instead,
+// which also revokes all the access tokens obtained with it.
+// The client should not be used to make requests afterwards.
+func (c *Client) RevokeToken(ctx context.Context) error {
+	if c.oauth2Transport == nil {
+		return errors.New("client is not authenticated via OAuth2")
+	}
+
+	token, err := c.oauth2Transport.Source.Token()
+	if err != nil {
+		return err
+	}
+
+	form := url.Values{}
+	if token.RefreshToken != "" {
+		form.Set("token", token.RefreshToken)
+		form.Set("token_type_hint", "refresh_token")
+	} else {
+		form.Set("token", token.AccessToken)
+		form.Set("token_type_hint", "access_token")
+	}
+
+	u, err := c.TokenURL.Parse("revoke_token")
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, u.String(), strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set(headerContentType, mediaTypeForm)
+	req.SetBasicAuth(c.ID, c.Secret)
+
+	// the token endpoints authenticate the app itself, not the user, so skip the OAuth2 transport
+	httpClient := &http.Client{Transport: c.oauth2Transport.Base}
+	resp, err := DoRequestWithClient(ctx, httpClient, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckResponse(resp); err != nil {
+		return err
+	}
+
+	if c.tokenStore != nil {
+		return c.tokenStore.Delete(ctx)
 	}
+	return nil
 }
@@ -0,0 +1,321 @@
+package reddit
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestAuthorizeURL(t *testing.T) {
+	u, err := url.Parse(AuthorizeURL("id1", "http://localhost:8080/callback", "state1", true, "identity", "read"))
+	require.NoError(t, err)
+	require.Equal(t, "https://www.reddit.com/api/v1/authorize", fmt.Sprintf("%s://%s%s", u.Scheme, u.Host, u.Path))
+	require.Equal(t, url.Values{
+		"client_id":     []string{"id1"},
+		"response_type": []string{"code"},
+		"state":         []string{"state1"},
+		"redirect_uri":  []string{"http://localhost:8080/callback"},
+		"duration":      []string{"permanent"},
+		"scope":         []string{"identity read"},
+	}, u.Query())
+
+	u, err = url.Parse(AuthorizeURL("id1", "http://localhost:8080/callback", "state1", false, "*"))
+	require.NoError(t, err)
+	require.Equal(t, "temporary", u.Query().Get("duration"))
+	require.Equal(t, "*", u.Query().Get("scope"))
+}
+
+func TestExchangeCode(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/api/v1/access_token", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "test-agent", r.Header.Get(headerUserAgent))
+
+		id, secret, ok := r.BasicAuth()
+		require.True(t, ok)
+		require.Equal(t, "id1", id)
+		require.Equal(t, "secret1", secret)
+
+		form := url.Values{}
+		form.Set("grant_type", "authorization_code")
+		form.Set("code", "code1")
+		form.Set("redirect_uri", "http://localhost:8080/callback")
+
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, form, r.PostForm)
+
+		w.Header().Add(headerContentType, mediaTypeJSON)
+		fmt.Fprint(w, `{
+			"access_token": "token1",
+			"token_type": "bearer",
+			"expires_in": 3600,
+			"refresh_token": "refresh1",
+			"scope": "identity read"
+		}`)
+	})
+
+	token, err := ExchangeCode(
+		ctx,
+		Credentials{ID: "id1", Secret: "secret1"},
+		"http://localhost:8080/callback",
+		"code1",
+		WithUserAgent("test-agent"),
+		WithTokenURL(server.URL+"/api/v1/access_token"),
+	)
+	require.NoError(t, err)
+	require.Equal(t, "token1", token.AccessToken)
+	require.Equal(t, "refresh1", token.RefreshToken)
+
+	_, err = ExchangeCode(ctx, Credentials{}, "", "", WithTokenURL(":"))
+	require.Error(t, err)
+}
+
+func TestNewClientWithToken(t *testing.T) {
+	_, err := NewClientWithToken(Credentials{}, nil)
+	require.EqualError(t, err, "*oauth2.Token: cannot be nil")
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	var refreshes int
+	mux.HandleFunc("/api/v1/access_token", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		refreshes++
+
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "refresh_token", r.PostForm.Get("grant_type"))
+		require.Equal(t, "refresh1", r.PostForm.Get("refresh_token"))
+
+		w.Header().Add(headerContentType, mediaTypeJSON)
+		fmt.Fprint(w, `{
+			"access_token": "token2",
+			"token_type": "bearer",
+			"expires_in": 3600,
+			"scope": "identity read"
+		}`)
+	})
+
+	var tokens []string
+	mux.HandleFunc("/api/v1/test", func(w http.ResponseWriter, r *http.Request) {
+		tokens = append(tokens, r.Header.Get("Authorization"))
+	})
+
+	token := &oauth2.Token{
+		AccessToken:  "token1",
+		TokenType:    "bearer",
+		RefreshToken: "refresh1",
+		Expiry:       time.Now().Add(time.Hour),
+	}
+
+	client, err := NewClientWithToken(
+		Credentials{ID: "id1", Secret: "secret1"},
+		token,
+		WithBaseURL(server.URL),
+		WithTokenURL(server.URL+"/api/v1/access_token"),
+	)
+	require.NoError(t, err)
+
+	req, err := client.NewRequest(http.MethodGet, "api/v1/test", nil)
+	require.NoError(t, err)
+
+	_, err = client.Do(ctx, req, nil)
+	require.NoError(t, err)
+	require.Equal(t, 0, refreshes)
+
+	// the token expired, so it gets refreshed
+	token.Expiry = time.Now().Add(-time.Minute)
+
+	client, err = NewClientWithToken(
+		Credentials{ID: "id1", Secret: "secret1"},
+		token,
+		WithBaseURL(server.URL),
+		WithTokenURL(server.URL+"/api/v1/access_token"),
+	)
+	require.NoError(t, err)
+
+	_, err = client.Do(ctx, req, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, refreshes)
+
+	require.Equal(t, []string{"Bearer token1", "Bearer token2"}, tokens)
+}
+
+func TestNewClientWithTokenSource(t *testing.T) {
+	_, err := NewClientWithTokenSource(nil)
+	require.EqualError(t, err, "oauth2.TokenSource: cannot be nil")
+
+	client, mux := setup(t)
+
+	mux.HandleFunc("/api/v1/test", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer static1", r.Header.Get("Authorization"))
+	})
+
+	client, err = NewClientWithTokenSource(
+		oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "static1"}),
+		WithBaseURL(client.BaseURL.String()),
+	)
+	require.NoError(t, err)
+	testClientServices(t, client)
+
+	req, err := client.NewRequest(http.MethodGet, "api/v1/test", nil)
+	require.NoError(t, err)
+
+	_, err = client.Do(ctx, req, nil)
+	require.NoError(t, err)
+}
+
+func TestNewApplicationClient(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/api/v1/access_token", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+
+		id, secret, ok := r.BasicAuth()
+		require.True(t, ok)
+		require.Equal(t, "id1", id)
+		require.Equal(t, "secret1", secret)
+
+		form := url.Values{}
+		form.Set("grant_type", "client_credentials")
+
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, form, r.PostForm)
+
+		w.Header().Add(headerContentType, mediaTypeJSON)
+		fmt.Fprint(w, `{
+			"access_token": "app1",
+			"token_type": "bearer",
+			"expires_in": 3600,
+			"scope": "*"
+		}`)
+	})
+
+	mux.HandleFunc("/api/v1/test", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer app1", r.Header.Get("Authorization"))
+	})
+
+	client, err := NewApplicationClient(
+		Credentials{ID: "id1", Secret: "secret1", Username: "user1", Password: "password1"},
+		WithBaseURL(server.URL),
+		WithTokenURL(server.URL+"/api/v1/access_token"),
+	)
+	require.NoError(t, err)
+	testClientServices(t, client)
+
+	req, err := client.NewRequest(http.MethodGet, "api/v1/test", nil)
+	require.NoError(t, err)
+
+	_, err = client.Do(ctx, req, nil)
+	require.NoError(t, err)
+}
+
+func TestNewInstalledApplicationClient(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	var deviceIDs []string
+	mux.HandleFunc("/api/v1/access_token", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+
+		id, secret, ok := r.BasicAuth()
+		require.True(t, ok)
+		require.Equal(t, "id1", id)
+		require.Equal(t, "", secret)
+
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "https://oauth.reddit.com/grants/installed_client", r.PostForm.Get("grant_type"))
+		deviceIDs = append(deviceIDs, r.PostForm.Get("device_id"))
+
+		w.Header().Add(headerContentType, mediaTypeJSON)
+		fmt.Fprint(w, `{
+			"access_token": "installed1",
+			"token_type": "bearer",
+			"expires_in": 3600,
+			"scope": "*"
+		}`)
+	})
+
+	mux.HandleFunc("/api/v1/test", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer installed1", r.Header.Get("Authorization"))
+	})
+
+	for _, deviceID := range []string{"device123456789012345", ""} {
+		client, err := NewInstalledApplicationClient(
+			"id1",
+			deviceID,
+			WithBaseURL(server.URL),
+			WithTokenURL(server.URL+"/api/v1/access_token"),
+		)
+		require.NoError(t, err)
+
+		req, err := client.NewRequest(http.MethodGet, "api/v1/test", nil)
+		require.NoError(t, err)
+
+		_, err = client.Do(ctx, req, nil)
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, []string{"device123456789012345", "DO_NOT_TRACK_THIS_DEVICE"}, deviceIDs)
+}
+
+func TestClient_RevokeToken(t *testing.T) {
+	client, mux := setup(t)
+
+	store := NewMemoryTokenStore()
+	require.NoError(t, store.Save(ctx, &oauth2.Token{
+		AccessToken:  "token1",
+		TokenType:    "bearer",
+		RefreshToken: "refresh1",
+		Expiry:       time.Now().Add(time.Hour),
+	}))
+
+	client, err := NewClientWithToken(
+		Credentials{ID: "id1", Secret: "secret1"},
+		&oauth2.Token{AccessToken: "token0"},
+		WithBaseURL(client.BaseURL.String()),
+		WithTokenURL(client.BaseURL.String()+"/api/v1/access_token"),
+		WithTokenStore(store),
+	)
+	require.NoError(t, err)
+
+	mux.HandleFunc("/api/v1/revoke_token", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+
+		id, secret, ok := r.BasicAuth()
+		require.True(t, ok)
+		require.Equal(t, "id1", id)
+		require.Equal(t, "secret1", secret)
+
+		form := url.Values{}
+		form.Set("token", "refresh1")
+		form.Set("token_type_hint", "refresh_token")
+
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, form, r.PostForm)
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	require.NoError(t, client.RevokeToken(ctx))
+
+	token, err := store.Load(ctx)
+	require.NoError(t, err)
+	require.Nil(t, token)
+
+	readonlyClient, err := NewReadonlyClient()
+	require.NoError(t, err)
+	require.EqualError(t, readonlyClient.RevokeToken(ctx), "client is not authenticated via OAuth2")
+}
@@ -0,0 +1,259 @@
+package reddit
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Observer is notified of the requests made by a client, e.g. to log or trace them (see WithObserver).
+// Every attempt at sending a request is reported, including the ones that fail before reaching Reddit,
+// e.g. because of a network error or because the rate limit was already exceeded.
+// Implementations must be safe for concurrent use.
+type Observer interface {
+	// OnRequestStart is called before every attempt at sending a request.
+	// The returned context is used to send the request and passed to OnRequestEnd, so it
+	// can carry values such as a trace span. If there is nothing to add, return ctx itself.
+	OnRequestStart(ctx context.Context, event *RequestStartEvent) context.Context
+	// OnRequestEnd is called after every attempt, with the context returned by OnRequestStart.
+	OnRequestEnd(ctx context.Context, event *RequestEndEvent)
+	// OnRetry is called when the client is about to retry a failed attempt (see WithRetryPolicy).
+	OnRetry(ctx context.Context, event *RetryEvent)
+}
+
+// RequestStartEvent is reported when an attempt at sending a request starts.
+type RequestStartEvent struct {
+	// Name of the method that made the request, e.g. Post.SubmitText.
+	// It's empty if the request was sent directly with Client.Do.
+	Operation string
+	Request   *http.Request
+	// Number of the attempt, starting at 1.
+	Attempt int
+}
+
+// RequestEndEvent is reported when an attempt at sending a request ends.
+type RequestEndEvent struct {
+	// Name of the method that made the request, e.g. Post.SubmitText.
+	// It's empty if the request was sent directly with Client.Do.
+	Operation string
+	Request   *http.Request
+	// Number of the attempt, starting at 1.
+	Attempt  int
+	Duration time.Duration
+	// The response, if any. It's nil if the request failed before getting one, e.g. because of a network error.
+	Response *Response
+	// Status code of the response, or 0 if there isn't one.
+	StatusCode int
+	// Rate limit information of the response, if any.
+	Rate Rate
+	// Whether the response was served from the client's cache (see WithCache).
+	Cached bool
+	Err    error
+}
+
+// RetryEvent is reported when a failed attempt is about to be retried.
+type RetryEvent struct {
+	// Name of the method that made the request, e.g. Post.SubmitText.
+	// It's empty if the request was sent directly with Client.Do.
+	Operation string
+	Request   *http.Request
+	// Number of the attempt that failed, starting at 1.
+	Attempt int
+	// How long the client waits before the next attempt.
+	Wait     time.Duration
+	Response *Response
+	Err      error
+}
+
+type operationContextKey struct{}
+
+const packagePrefix = "github.com/vartanbeno/go-reddit/v2/reddit."
+
+var serviceMethodRegex = regexp.MustCompile(`^\(\*(\w+)Service\)\.([A-Z]\w*)$`)
+
+// callerOperation returns the name of the service method that led to the caller being called, e.g. Post.SubmitText.
+// It is the outermost exported service method among the calls made within this package.
+func callerOperation() string {
+	pcs := make([]uintptr, 32)
+	// skip runtime.Callers, this function and its caller
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var operation string
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, packagePrefix) {
+			break
+		}
+		if m := serviceMethodRegex.FindStringSubmatch(strings.TrimPrefix(frame.Function, packagePrefix)); m != nil {
+			operation = m[1] + "." + m[2]
+		}
+		if !more {
+			break
+		}
+	}
+	return operation
+}
+
+// withOperation returns a copy of the context whose requests are attributed to the operation, e.g. Stream.Comments.
+// Methods making requests without going through an exported service method use it to name their operation.
+func withOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, operationContextKey{}, operation)
+}
+
+func operationFromContext(ctx context.Context) string {
+	operation, _ := ctx.Value(operationContextKey{}).(string)
+	return operation
+}
+
+// observe sends the request once, notifying the client's observers.
+func (c *Client) observe(ctx context.Context, req *http.Request, v interface{}, attempt int) (*Response, error) {
+	if len(c.observers) == 0 {
+		return c.do(ctx, req, v)
+	}
+
+	operation := operationFromContext(ctx)
+	for _, o := range c.observers {
+		ctx = o.OnRequestStart(ctx, &RequestStartEvent{Operation: operation, Request: req, Attempt: attempt})
+	}
+
+	start := time.Now()
+	resp, err := c.do(ctx, req, v)
+
+	event := &RequestEndEvent{
+		Operation: operation,
+		Request:   req,
+		Attempt:   attempt,
+		Duration:  time.Since(start),
+		Response:  resp,
+		Err:       err,
+	}
+	if resp != nil {
+		if resp.Response != nil {
+			event.StatusCode = resp.StatusCode
+		}
+		event.Rate = resp.Rate
+		event.Cached = resp.Cached
+	}
+
+	// in reverse order, so that observers are nested like middleware
+	for i := len(c.observers) - 1; i >= 0; i-- {
+		c.observers[i].OnRequestEnd(ctx, event)
+	}
+
+	return resp, err
+}
+
+func (c *Client) notifyRetry(ctx context.Context, event *RetryEvent) {
+	event.Operation = operationFromContext(ctx)
+	for _, o := range c.observers {
+		o.OnRetry(ctx, event)
+	}
+}
+
+// Logger is a structured logger, such as the *slog.Logger of the log/slog package.
+// Arguments are alternating keys and values.
+type Logger interface {
+	DebugContext(ctx context.Context, msg string, args ...interface{})
+	InfoContext(ctx context.Context, msg string, args ...interface{})
+	WarnContext(ctx context.Context, msg string, args ...interface{})
+	ErrorContext(ctx context.Context, msg string, args ...interface{})
+}
+
+// LogObserver is an Observer that logs requests with a structured logger.
+// Starts are logged at the debug level, retries at the warn level, and ends at the
+// info level, or at the error level if the request failed.
+type LogObserver struct {
+	logger Logger
+}
+
+// NewLogObserver returns a new LogObserver that logs to the logger.
+func NewLogObserver(logger Logger) *LogObserver {
+	return &LogObserver{logger: logger}
+}
+
+// OnRequestStart logs the start of the request.
+func (o *LogObserver) OnRequestStart(ctx context.Context, event *RequestStartEvent) context.Context {
+	o.logger.DebugContext(ctx, "reddit: request started",
+		"operation", event.Operation,
+		"method", event.Request.Method,
+		"path", event.Request.URL.Path,
+		"attempt", event.Attempt,
+	)
+	return ctx
+}
+
+// OnRequestEnd logs the end of the request.
+func (o *LogObserver) OnRequestEnd(ctx context.Context, event *RequestEndEvent) {
+	args := []interface{}{
+		"operation", event.Operation,
+		"method", event.Request.Method,
+		"path", event.Request.URL.Path,
+		"attempt", event.Attempt,
+		"status", event.StatusCode,
+		"duration", event.Duration,
+		"rate_remaining", event.Rate.Remaining,
+		"rate_reset", event.Rate.Reset,
+		"cached", event.Cached,
+	}
+
+	if event.Err != nil {
+		args = append(args, "error", event.Err)
+		o.logger.ErrorContext(ctx, "reddit: request failed", args...)
+		return
+	}
+	o.logger.InfoContext(ctx, "reddit: request completed", args...)
+}
+
+// OnRetry logs the retry of the request.
+func (o *LogObserver) OnRetry(ctx context.Context, event *RetryEvent) {
+	o.logger.WarnContext(ctx, "reddit: retrying request",
+		"operation", event.Operation,
+		"method", event.Request.Method,
+		"path", event.Request.URL.Path,
+		"attempt", event.Attempt,
+		"wait", event.Wait,
+		"error", event.Err,
+	)
+}
+
+// StartSpanFunc starts a span of a tracing system for an attempt at sending a request, as a child of the
+// span in the context if any. It returns a context carrying the new span, which is used to send the request
+// (so that the HTTP transport can propagate it), and a function ending the span with the outcome of the attempt.
+type StartSpanFunc func(ctx context.Context, event *RequestStartEvent) (context.Context, func(event *RequestEndEvent))
+
+// TraceObserver is an Observer that traces requests with spans.
+type TraceObserver struct {
+	startSpan StartSpanFunc
+}
+
+type spanContextKey struct {
+	o *TraceObserver
+}
+
+// NewTraceObserver returns a new TraceObserver that starts spans with the function.
+func NewTraceObserver(startSpan StartSpanFunc) *TraceObserver {
+	return &TraceObserver{startSpan: startSpan}
+}
+
+// OnRequestStart starts a span.
+func (o *TraceObserver) OnRequestStart(ctx context.Context, event *RequestStartEvent) context.Context {
+	ctx, end := o.startSpan(ctx, event)
+	if end == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, spanContextKey{o}, end)
+}
+
+// OnRequestEnd ends the span started by OnRequestStart.
+func (o *TraceObserver) OnRequestEnd(ctx context.Context, event *RequestEndEvent) {
+	if end, ok := ctx.Value(spanContextKey{o}).(func(*RequestEndEvent)); ok {
+		end(event)
+	}
+}
+
+// OnRetry does nothing, since every attempt gets its own span.
+func (o *TraceObserver) OnRetry(_ context.Context, _ *RetryEvent) {}
@@ -0,0 +1,239 @@
+package reddit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testObserver struct {
+	events []interface{}
+}
+
+func (o *testObserver) OnRequestStart(ctx context.Context, event *RequestStartEvent) context.Context {
+	o.events = append(o.events, event)
+	return ctx
+}
+
+func (o *testObserver) OnRequestEnd(ctx context.Context, event *RequestEndEvent) {
+	o.events = append(o.events, event)
+}
+
+func (o *testObserver) OnRetry(ctx context.Context, event *RetryEvent) {
+	o.events = append(o.events, event)
+}
+
+func TestClient_Observer(t *testing.T) {
+	client, mux := setup(t)
+
+	observer := new(testObserver)
+	require.NoError(t, WithObserver(observer)(client))
+	require.NoError(t, WithRetryPolicy(testRetryPolicy)(client))
+
+	var counter int
+	mux.HandleFunc("/r/golang/about", func(w http.ResponseWriter, r *http.Request) {
+		counter++
+		if counter == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set(headerRateLimitRemaining, "599")
+		w.Header().Set(headerRateLimitUsed, "1")
+		w.Header().Set(headerRateLimitReset, "300")
+		fmt.Fprint(w, `{"kind": "t5", "data": {"display_name": "golang"}}`)
+	})
+
+	_, _, err := client.Subreddit.Get(ctx, "golang")
+	require.NoError(t, err)
+	require.Len(t, observer.events, 5)
+
+	start := observer.events[0].(*RequestStartEvent)
+	require.Equal(t, "Subreddit.Get", start.Operation)
+	require.Equal(t, 1, start.Attempt)
+
+	end := observer.events[1].(*RequestEndEvent)
+	require.Equal(t, "Subreddit.Get", end.Operation)
+	require.Equal(t, http.StatusServiceUnavailable, end.StatusCode)
+	require.Error(t, end.Err)
+
+	retry := observer.events[2].(*RetryEvent)
+	require.Equal(t, "Subreddit.Get", retry.Operation)
+	require.Equal(t, 1, retry.Attempt)
+	require.Equal(t, end.Err, retry.Err)
+
+	start = observer.events[3].(*RequestStartEvent)
+	require.Equal(t, 2, start.Attempt)
+
+	end = observer.events[4].(*RequestEndEvent)
+	require.Equal(t, "Subreddit.Get", end.Operation)
+	require.Equal(t, http.StatusOK, end.StatusCode)
+	require.Equal(t, 599, end.Rate.Remaining)
+	require.NotNil(t, end.Response)
+	require.NoError(t, end.Err)
+}
+
+func TestClient_Observer_RateLimitExceeded(t *testing.T) {
+	client, mux := setup(t)
+
+	observer := new(testObserver)
+	require.NoError(t, WithObserver(observer)(client))
+
+	mux.HandleFunc("/api/v1/test", func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("the request should not have been sent")
+	})
+
+	client.rate = Rate{Remaining: 0, Reset: time.Now().Add(time.Minute)}
+
+	req, err := client.NewRequest(http.MethodGet, "api/v1/test", nil)
+	require.NoError(t, err)
+
+	_, err = client.Do(ctx, req, nil)
+	require.IsType(t, &RateLimitError{}, err)
+	require.Len(t, observer.events, 2)
+
+	// the request was sent directly, not through a service
+	end := observer.events[1].(*RequestEndEvent)
+	require.Empty(t, end.Operation)
+	require.Equal(t, http.StatusTooManyRequests, end.StatusCode)
+	require.Equal(t, err, end.Err)
+}
+
+func TestClient_Observer_NetworkError(t *testing.T) {
+	client, _ := setup(t)
+
+	observer := new(testObserver)
+	require.NoError(t, WithObserver(observer)(client))
+	require.NoError(t, WithBaseURL("http://127.0.0.1:0")(client))
+
+	_, _, err := client.Post.Get(ctx, "abc")
+	require.Error(t, err)
+	require.Len(t, observer.events, 2)
+
+	end := observer.events[1].(*RequestEndEvent)
+	require.Equal(t, "Post.Get", end.Operation)
+	require.Nil(t, end.Response)
+	require.Zero(t, end.StatusCode)
+	require.Equal(t, err, end.Err)
+}
+
+func TestClient_Observer_PostAndComment(t *testing.T) {
+	client, mux := setup(t)
+
+	observer := new(testObserver)
+	require.NoError(t, WithObserver(observer)(client))
+
+	mux.HandleFunc("/api/vote", func(w http.ResponseWriter, r *http.Request) {})
+
+	_, err := client.Post.Upvote(ctx, "t3_test")
+	require.NoError(t, err)
+	_, err = client.Comment.Upvote(ctx, "t1_test")
+	require.NoError(t, err)
+
+	require.Len(t, observer.events, 4)
+	require.Equal(t, "Post.Upvote", observer.events[0].(*RequestStartEvent).Operation)
+	require.Equal(t, "Comment.Upvote", observer.events[2].(*RequestStartEvent).Operation)
+}
+
+type testLogger struct {
+	entries []string
+}
+
+func (l *testLogger) log(level, msg string, args ...interface{}) {
+	l.entries = append(l.entries, strings.TrimSpace(fmt.Sprintln(append([]interface{}{level, msg}, args...)...)))
+}
+
+func (l *testLogger) DebugContext(_ context.Context, msg string, args ...interface{}) {
+	l.log("DEBUG", msg, args...)
+}
+
+func (l *testLogger) InfoContext(_ context.Context, msg string, args ...interface{}) {
+	l.log("INFO", msg, args...)
+}
+
+func (l *testLogger) WarnContext(_ context.Context, msg string, args ...interface{}) {
+	l.log("WARN", msg, args...)
+}
+
+func (l *testLogger) ErrorContext(_ context.Context, msg string, args ...interface{}) {
+	l.log("ERROR", msg, args...)
+}
+
+func TestLogObserver(t *testing.T) {
+	client, mux := setup(t)
+
+	logger := new(testLogger)
+	require.NoError(t, WithObserver(NewLogObserver(logger))(client))
+
+	mux.HandleFunc("/r/golang/about", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"kind": "t5", "data": {}}`)
+	})
+	mux.HandleFunc("/r/golang/about/rules", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	_, _, err := client.Subreddit.Get(ctx, "golang")
+	require.NoError(t, err)
+
+	_, _, err = client.Subreddit.Rules(ctx, "golang")
+	require.Error(t, err)
+
+	require.Len(t, logger.entries, 4)
+	require.Contains(t, logger.entries[0], "DEBUG reddit: request started operation Subreddit.Get method GET path /r/golang/about attempt 1")
+	require.Contains(t, logger.entries[1], "INFO reddit: request completed operation Subreddit.Get")
+	require.Contains(t, logger.entries[1], "status 200")
+	require.Contains(t, logger.entries[3], "ERROR reddit: request failed operation Subreddit.Rules")
+	require.Contains(t, logger.entries[3], "status 403")
+}
+
+type spanContextTestKey struct{}
+
+func TestTraceObserver(t *testing.T) {
+	client, mux := setup(t)
+
+	var ended []*RequestEndEvent
+	tracer := NewTraceObserver(func(ctx context.Context, event *RequestStartEvent) (context.Context, func(*RequestEndEvent)) {
+		ctx = context.WithValue(ctx, spanContextTestKey{}, event.Operation)
+		return ctx, func(event *RequestEndEvent) {
+			ended = append(ended, event)
+		}
+	})
+
+	var span interface{}
+	require.NoError(t, WithObserver(tracer, observerFunc(func(ctx context.Context) {
+		span = ctx.Value(spanContextTestKey{})
+	}))(client))
+
+	mux.HandleFunc("/api/comment", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": "test"}`)
+	})
+
+	_, _, err := client.Comment.Submit(ctx, "t3_test", "test")
+	require.NoError(t, err)
+
+	// the span is in the context of the observers added after the tracer
+	require.Equal(t, "Comment.Submit", span)
+	require.Len(t, ended, 1)
+	require.Equal(t, "Comment.Submit", ended[0].Operation)
+}
+
+// observerFunc is an Observer that calls the function with the context of every event.
+type observerFunc func(ctx context.Context)
+
+func (f observerFunc) OnRequestStart(ctx context.Context, _ *RequestStartEvent) context.Context {
+	f(ctx)
+	return ctx
+}
+
+func (f observerFunc) OnRequestEnd(ctx context.Context, _ *RequestEndEvent) { f(ctx) }
+
+func (f observerFunc) OnRetry(ctx context.Context, _ *RetryEvent) { f(ctx) }
+
+func TestWithObserver_Error(t *testing.T) {
+	_, err := NewClient(Credentials{}, WithObserver(nil))
+	require.EqualError(t, err, "Observer: cannot be nil")
+}
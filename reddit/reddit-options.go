@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"time"
 )
 
 // Opt is used to further configure a client upon initialization.
@@ -76,3 +77,116 @@ func FromEnv(c *Client) error {
 	}
 	return nil
 }
+
+// WithRetryPolicy configures the client to retry requests that fail with a 429 Too Many Requests
+// or a 5xx server error, according to the provided policy.
+func WithRetryPolicy(policy RetryPolicy) Opt {
+	return func(c *Client) error {
+		if policy.MaxRetries < 0 {
+			return errors.New("RetryPolicy: MaxRetries cannot be negative")
+		}
+		c.retryPolicy = &policy
+		return nil
+	}
+}
+
+// WithRateLimiter sets the rate limiter used to pace the client's requests.
+// Instead of failing with a *RateLimitError when the rate limit has been exceeded, the client
+// will wait for the limiter before making each request, e.g. WithRateLimiter(NewSpreadRateLimiter()).
+func WithRateLimiter(limiter RateLimiter) Opt {
+	return func(c *Client) error {
+		if limiter == nil {
+			return errors.New("RateLimiter: cannot be nil")
+		}
+		c.rateLimiter = limiter
+		return nil
+	}
+}
+
+// WithRateBudget attaches the client to a rate budget, which holds the rate limit state instead of the client.
+// Clients authenticating with the same app can share a budget so that together they don't exceed the app's quota.
+// Combine it with a shared RateLimiter (see WithRateLimiter) to have the clients wait for their turn instead of
+// failing with a *RateLimitError once the budget is exhausted.
+func WithRateBudget(budget RateBudget) Opt {
+	return func(c *Client) error {
+		if budget == nil {
+			return errors.New("RateBudget: cannot be nil")
+		}
+		c.rateBudget = budget
+		return nil
+	}
+}
+
+// WithMiddleware adds middleware that wraps every request made with the client.
+// Middleware is applied in the order it is added, i.e. the first one is the outermost.
+func WithMiddleware(middleware ...Middleware) Opt {
+	return func(c *Client) error {
+		for _, m := range middleware {
+			if m == nil {
+				return errors.New("Middleware: cannot be nil")
+			}
+		}
+		c.middleware = append(c.middleware, middleware...)
+		return nil
+	}
+}
+
+// WithTokenStore sets the store the client's access token is persisted to.
+// When the client is initialized, it uses the stored token (if any) until it expires,
+// instead of requesting a new one. Every new token it obtains is saved to the store.
+func WithTokenStore(store TokenStore) Opt {
+	return func(c *Client) error {
+		if store == nil {
+			return errors.New("TokenStore: cannot be nil")
+		}
+		c.tokenStore = store
+		return nil
+	}
+}
+
+// WithCache caches the responses to GET requests made with the client, e.g. WithCache(NewMemoryCache(1000), nil).
+// Responses are cached for the TTL of their endpoint's class, and those of classes without a TTL aren't cached.
+// If ttls is nil, DefaultCacheTTLs is used. Once a response expires, it is revalidated with a conditional
+// request if Reddit returned an ETag or Last-Modified header for it.
+// Requests that modify something, e.g. adding a subreddit rule, remove the cached responses they affect.
+func WithCache(cache Cache, ttls map[CacheClass]time.Duration) Opt {
+	return func(c *Client) error {
+		if cache == nil {
+			return errors.New("Cache: cannot be nil")
+		}
+		if ttls == nil {
+			ttls = DefaultCacheTTLs
+		}
+		c.cache = cache
+		c.cacheTTLs = ttls
+		return nil
+	}
+}
+
+// WithObserver adds observers that are notified of every request made with the client,
+// e.g. WithObserver(NewLogObserver(slog.Default())).
+// Observers are notified of the start of requests in the order they are added, and of their end in reverse order.
+func WithObserver(observers ...Observer) Opt {
+	return func(c *Client) error {
+		for _, o := range observers {
+			if o == nil {
+				return errors.New("Observer: cannot be nil")
+			}
+		}
+		c.observers = append(c.observers, observers...)
+		return nil
+	}
+}
+
+// WithListingsConcurrency sets how many requests ListingsService methods make at the same time
+// when getting more than 100 things, which Reddit only returns 100 at a time. The default is 1,
+// i.e. the requests are made sequentially.
+func WithListingsConcurrency(n int) Opt {
+	return func(c *Client) error {
+		if n < 1 {
+			return errors.New("listings concurrency: must be at least 1")
+		}
+		c.listingsConcurrency = n
+		return nil
+	}
+}
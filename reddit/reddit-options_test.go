@@ -72,3 +72,12 @@ func TestFromEnv(t *testing.T) {
 	require.Equal(t, "username1", c.Username)
 	require.Equal(t, "password1", c.Password)
 }
+
+func TestWithListingsConcurrency(t *testing.T) {
+	_, err := NewClient(Credentials{}, WithListingsConcurrency(0))
+	require.EqualError(t, err, "listings concurrency: must be at least 1")
+
+	c, err := NewClient(Credentials{}, WithListingsConcurrency(4))
+	require.NoError(t, err)
+	require.Equal(t, 4, c.listingsConcurrency)
+}
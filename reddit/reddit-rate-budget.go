@@ -0,0 +1,76 @@
+package reddit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateBudget holds rate limit state that can be shared by multiple clients.
+// Clients using the same OAuth app share the same rate limit on Reddit's end, so attaching
+// them to the same budget (via WithRateBudget) lets them coordinate through a single Rate.
+// Implementations must be safe for concurrent use. They may be backed by an external store,
+// in order to share the budget between processes.
+type RateBudget interface {
+	// Rate returns the current rate limit.
+	Rate(ctx context.Context) (Rate, error)
+	// Update records the rate limit reported by a response from Reddit.
+	Update(ctx context.Context, rate Rate) error
+}
+
+// MemoryRateBudget is an in-memory RateBudget.
+// The zero value is ready to use.
+type MemoryRateBudget struct {
+	mu   sync.Mutex
+	rate Rate
+}
+
+// NewMemoryRateBudget returns a new MemoryRateBudget.
+func NewMemoryRateBudget() *MemoryRateBudget {
+	return new(MemoryRateBudget)
+}
+
+// Rate returns the current rate limit.
+func (b *MemoryRateBudget) Rate(_ context.Context) (Rate, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.rate, nil
+}
+
+// Update records the rate limit reported by a response from Reddit.
+func (b *MemoryRateBudget) Update(_ context.Context, rate Rate) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.rate = MergeRate(b.rate, rate)
+	return nil
+}
+
+// rateResetTolerance is how far apart the resets of 2 rates can be while still being
+// considered part of the same window. Resets are only precise to the second, and the
+// responses they come from can be received at slightly different times.
+const rateResetTolerance = time.Second * 2
+
+// MergeRate combines the current rate limit of a budget with one reported by a new response.
+// Responses to concurrent requests can arrive out of order, so within the same window the most
+// conservative values are kept, and rates from a previous window are ignored.
+// Rates without a reset time (e.g. from responses without rate limit headers) are ignored as well.
+func MergeRate(current, rate Rate) Rate {
+	switch {
+	case rate.Reset.IsZero():
+		return current
+	case current.Reset.IsZero(), rate.Reset.After(current.Reset.Add(rateResetTolerance)):
+		// new window
+		return rate
+	case rate.Reset.Before(current.Reset.Add(-rateResetTolerance)):
+		// previous window
+		return current
+	}
+
+	if rate.Remaining < current.Remaining {
+		current.Remaining = rate.Remaining
+	}
+	if rate.Used > current.Used {
+		current.Used = rate.Used
+	}
+	return current
+}
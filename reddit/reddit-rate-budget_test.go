@@ -0,0 +1,100 @@
+package reddit
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeRate(t *testing.T) {
+	reset := time.Now().Truncate(time.Second).Add(time.Minute * 5)
+	current := Rate{Remaining: 100, Used: 500, Reset: reset}
+
+	// rate without any information
+	require.Equal(t, current, MergeRate(current, Rate{}))
+
+	// no current rate
+	require.Equal(t, current, MergeRate(Rate{}, current))
+
+	// new window
+	next := Rate{Remaining: 599, Used: 1, Reset: reset.Add(time.Minute * 10)}
+	require.Equal(t, next, MergeRate(current, next))
+
+	// previous window
+	previous := Rate{Remaining: 10, Used: 590, Reset: reset.Add(-time.Minute * 10)}
+	require.Equal(t, current, MergeRate(current, previous))
+
+	// same window, out of order response
+	require.Equal(t, current, MergeRate(current, Rate{Remaining: 105, Used: 495, Reset: reset.Add(-time.Second)}))
+
+	// same window, more recent response
+	require.Equal(t, Rate{Remaining: 99, Used: 501, Reset: reset}, MergeRate(current, Rate{Remaining: 99, Used: 501, Reset: reset.Add(time.Second)}))
+}
+
+func TestMemoryRateBudget(t *testing.T) {
+	budget := NewMemoryRateBudget()
+
+	rate, err := budget.Rate(ctx)
+	require.NoError(t, err)
+	require.Equal(t, Rate{}, rate)
+
+	expected := Rate{Remaining: 100, Used: 500, Reset: time.Now().Add(time.Minute)}
+	require.NoError(t, budget.Update(ctx, expected))
+
+	rate, err = budget.Rate(ctx)
+	require.NoError(t, err)
+	require.Equal(t, expected, rate)
+}
+
+func TestClient_Do_RateBudget(t *testing.T) {
+	budget := NewMemoryRateBudget()
+
+	client1, mux1 := setup(t)
+	require.NoError(t, WithRateBudget(budget)(client1))
+
+	client2, mux2 := setup(t)
+	require.NoError(t, WithRateBudget(budget)(client2))
+
+	var counter int
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		counter++
+
+		w.Header().Set(headerRateLimitRemaining, "0")
+		w.Header().Set(headerRateLimitUsed, "600")
+		w.Header().Set(headerRateLimitReset, "240")
+	}
+	mux1.HandleFunc("/api/v1/test", handler)
+	mux2.HandleFunc("/api/v1/test", handler)
+
+	req, err := client1.NewRequest(http.MethodGet, "api/v1/test", nil)
+	require.NoError(t, err)
+
+	_, err = client1.Do(ctx, req, nil)
+	require.IsType(t, &RateLimitError{}, err)
+	require.Equal(t, 1, counter)
+
+	rate, err := budget.Rate(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, rate.Remaining)
+	require.Equal(t, 600, rate.Used)
+
+	// the 2nd client uses the same budget, so it doesn't make the request
+	req, err = client2.NewRequest(http.MethodGet, "api/v1/test", nil)
+	require.NoError(t, err)
+
+	resp, err := client2.Do(ctx, req, nil)
+	require.IsType(t, &RateLimitError{}, err)
+	require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	require.Equal(t, 1, counter)
+}
+
+func TestWithRateBudget(t *testing.T) {
+	_, err := NewClient(Credentials{}, WithRateBudget(nil))
+	require.EqualError(t, err, "RateBudget: cannot be nil")
+
+	_, err = NewClient(Credentials{}, WithRateBudget(NewMemoryRateBudget()))
+	require.NoError(t, err)
+}
@@ -0,0 +1,76 @@
+package reddit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter paces the requests made by a client.
+// Wait is called before every request with the client's last known rate limit.
+// It should block until the request can be sent, or return an error if the context is done first.
+type RateLimiter interface {
+	Wait(ctx context.Context, rate Rate) error
+}
+
+// SpreadRateLimiter is a RateLimiter that spreads requests evenly across Reddit's rate limit window.
+// It uses the remaining number of requests and the time left until the window resets to
+// space requests out, and waits until the reset if no requests remain.
+// The zero value is ready to use.
+type SpreadRateLimiter struct {
+	mu sync.Mutex
+	// The time at which the last request was allowed to be sent.
+	last time.Time
+}
+
+// NewSpreadRateLimiter returns a new SpreadRateLimiter.
+func NewSpreadRateLimiter() *SpreadRateLimiter {
+	return new(SpreadRateLimiter)
+}
+
+// Wait blocks until the request can be sent, or until the context is done.
+func (l *SpreadRateLimiter) Wait(ctx context.Context, rate Rate) error {
+	l.mu.Lock()
+	wait := l.reserve(time.Now(), rate)
+	l.mu.Unlock()
+
+	return sleep(ctx, wait)
+}
+
+// reserve returns how long the caller needs to wait before sending its request,
+// and reserves that slot so that concurrent callers are queued after it.
+func (l *SpreadRateLimiter) reserve(now time.Time, rate Rate) time.Duration {
+	var wait time.Duration
+
+	switch {
+	case rate.Reset.IsZero() || !now.Before(rate.Reset):
+		// the rate limit is unknown, or the window has already reset
+	case rate.Remaining <= 0:
+		wait = rate.Reset.Sub(now)
+	default:
+		interval := rate.Reset.Sub(now) / time.Duration(rate.Remaining)
+		if next := l.last.Add(interval); next.After(now) {
+			wait = next.Sub(now)
+		}
+	}
+
+	l.last = now.Add(wait)
+	return wait
+}
+
+// sleep pauses the current goroutine for the duration d, or until the context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
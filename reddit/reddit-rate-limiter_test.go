@@ -0,0 +1,88 @@
+package reddit
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpreadRateLimiter_Reserve(t *testing.T) {
+	limiter := NewSpreadRateLimiter()
+	now := time.Now()
+
+	// unknown rate limit
+	require.Equal(t, time.Duration(0), limiter.reserve(now, Rate{}))
+
+	// window has already reset
+	require.Equal(t, time.Duration(0), limiter.reserve(now, Rate{Remaining: 0, Reset: now.Add(-time.Second)}))
+
+	// no requests remaining, wait until the reset
+	require.Equal(t, time.Minute, limiter.reserve(now, Rate{Remaining: 0, Reset: now.Add(time.Minute)}))
+
+	// 10 requests remaining in the next 100 seconds, so 1 request every 10 seconds
+	limiter = NewSpreadRateLimiter()
+	rate := Rate{Remaining: 10, Reset: now.Add(time.Second * 100)}
+	require.Equal(t, time.Duration(0), limiter.reserve(now, rate))
+	require.Equal(t, time.Second*10, limiter.reserve(now, rate))
+	require.Equal(t, time.Second*20, limiter.reserve(now, rate))
+
+	// 75 seconds left for 10 requests, so the interval shrinks to 7.5 seconds
+	require.Equal(t, time.Millisecond*2500, limiter.reserve(now.Add(time.Second*25), rate))
+}
+
+func TestSpreadRateLimiter_Wait(t *testing.T) {
+	limiter := NewSpreadRateLimiter()
+
+	err := limiter.Wait(ctx, Rate{Remaining: 0, Reset: time.Now().Add(time.Millisecond * 10)})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(ctx, time.Millisecond*10)
+	defer cancel()
+
+	err = limiter.Wait(ctx, Rate{Remaining: 0, Reset: time.Now().Add(time.Minute)})
+	require.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestClient_Do_RateLimiter(t *testing.T) {
+	client, mux := setup(t)
+	require.NoError(t, WithRateLimiter(NewSpreadRateLimiter())(client))
+
+	var counter int
+	mux.HandleFunc("/api/v1/test", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		counter++
+	})
+
+	req, err := client.NewRequest(http.MethodGet, "api/v1/test", nil)
+	require.NoError(t, err)
+
+	// without a rate limiter, this would fail immediately with a *RateLimitError
+	client.rate.Remaining = 0
+	client.rate.Reset = time.Now().Add(time.Millisecond * 50)
+
+	resp, err := client.Do(ctx, req, nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, 1, counter)
+
+	client.rate.Remaining = 0
+	client.rate.Reset = time.Now().Add(time.Minute)
+
+	ctx, cancel := context.WithTimeout(ctx, time.Millisecond*10)
+	defer cancel()
+
+	_, err = client.Do(ctx, req, nil)
+	require.Equal(t, context.DeadlineExceeded, err)
+	require.Equal(t, 1, counter)
+}
+
+func TestWithRateLimiter(t *testing.T) {
+	_, err := NewClient(Credentials{}, WithRateLimiter(nil))
+	require.EqualError(t, err, "RateLimiter: cannot be nil")
+
+	_, err = NewClient(Credentials{}, WithRateLimiter(NewSpreadRateLimiter()))
+	require.NoError(t, err)
+}
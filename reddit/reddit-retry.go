@@ -0,0 +1,181 @@
+package reddit
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRetryMinBackoff = time.Second
+	defaultRetryMaxBackoff = time.Second * 30
+
+	headerRetryAfter = "Retry-After"
+)
+
+// RetryPolicy configures how the client retries requests that fail with a
+// 429 Too Many Requests or a 500, 502, 503 or 504 server error.
+// Between attempts, the client waits for the duration given by Reddit (via the
+// Retry-After or x-ratelimit-reset headers), or for a jittered exponential backoff.
+// The client never waits past the deadline of the request's context.
+type RetryPolicy struct {
+	// Maximum number of retries after the initial attempt.
+	MaxRetries int
+	// Backoff before the first retry. It doubles after every attempt.
+	// If 0 or less, the default of 1 second is used.
+	MinBackoff time.Duration
+	// Maximum backoff between attempts.
+	// If 0 or less, the default of 30 seconds is used.
+	MaxBackoff time.Duration
+	// By default, only idempotent requests (GET, HEAD, OPTIONS, PUT, DELETE) are retried.
+	// If true, other requests (e.g. POST) will be retried as well.
+	RetryNonIdempotent bool
+}
+
+func (p *RetryPolicy) minBackoff() time.Duration {
+	if p.MinBackoff > 0 {
+		return p.MinBackoff
+	}
+	return defaultRetryMinBackoff
+}
+
+func (p *RetryPolicy) maxBackoff() time.Duration {
+	if p.MaxBackoff > 0 {
+		return p.MaxBackoff
+	}
+	return defaultRetryMaxBackoff
+}
+
+// backoff returns how long to wait before the next attempt.
+// attempt is the number of attempts made so far, minus 1.
+func (p *RetryPolicy) backoff(attempt int, resp *Response) time.Duration {
+	if d, ok := retryAfter(resp.Response); ok {
+		return d
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests && !resp.Rate.Reset.IsZero() {
+		if d := time.Until(resp.Rate.Reset); d > 0 {
+			return d
+		}
+	}
+
+	d := p.maxBackoff()
+	if attempt < 32 {
+		if exp := p.minBackoff() << uint(attempt); exp > 0 && exp < d {
+			d = exp
+		}
+	}
+
+	// wait somewhere between half and all of the backoff, so that
+	// multiple clients failing at the same time don't retry in lockstep
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+func (p *RetryPolicy) retryable(req *http.Request) bool {
+	if p.RetryNonIdempotent {
+		return true
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryAfter parses the Retry-After header, which is either a number of seconds or an HTTP date.
+func retryAfter(r *http.Response) (time.Duration, bool) {
+	if r == nil {
+		return 0, false
+	}
+
+	v := r.Header.Get(headerRetryAfter)
+	if v == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(v); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+
+	return 0, false
+}
+
+func shouldRetry(resp *Response) bool {
+	if resp == nil || resp.Response == nil {
+		return false
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// rewindBody resets the request's body so that it can be sent again.
+// Requests created via NewRequest and NewJSONRequest can always be rewound.
+func rewindBody(req *http.Request) bool {
+	if req.Body == nil || req.Body == http.NoBody {
+		return true
+	}
+	if req.GetBody == nil {
+		return false
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return false
+	}
+	req.Body = body
+
+	return true
+}
+
+// doWithRetry sends the request, retrying it according to the client's retry policy.
+func (c *Client) doWithRetry(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+	policy := c.retryPolicy
+	retryable := policy.retryable(req)
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.observe(ctx, req, v, attempt+1)
+		if err == nil || !retryable || attempt >= policy.MaxRetries || !shouldRetry(resp) {
+			return resp, err
+		}
+
+		wait := policy.backoff(attempt, resp)
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+			return resp, err
+		}
+
+		if !rewindBody(req) {
+			return resp, err
+		}
+
+		c.notifyRetry(ctx, &RetryEvent{Request: req, Attempt: attempt + 1, Wait: wait, Response: resp, Err: err})
+
+		if err := sleep(ctx, wait); err != nil {
+			return resp, err
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package reddit
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	MinBackoff: time.Millisecond,
+	MaxBackoff: time.Millisecond * 5,
+}
+
+func TestClient_Do_Retry(t *testing.T) {
+	client, mux := setup(t)
+	require.NoError(t, WithRetryPolicy(testRetryPolicy)(client))
+
+	var counter int
+	mux.HandleFunc("/api/v1/test", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		defer func() { counter++ }()
+
+		switch counter {
+		case 0:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 1:
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	})
+
+	req, err := client.NewRequest(http.MethodGet, "api/v1/test", nil)
+	require.NoError(t, err)
+
+	resp, err := client.Do(ctx, req, nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, 3, counter)
+}
+
+func TestClient_Do_Retry_MaxRetries(t *testing.T) {
+	client, mux := setup(t)
+	require.NoError(t, WithRetryPolicy(testRetryPolicy)(client))
+
+	var counter int
+	mux.HandleFunc("/api/v1/test", func(w http.ResponseWriter, r *http.Request) {
+		counter++
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	req, err := client.NewRequest(http.MethodGet, "api/v1/test", nil)
+	require.NoError(t, err)
+
+	resp, err := client.Do(ctx, req, nil)
+	require.IsType(t, &ErrorResponse{}, err)
+	require.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	require.Equal(t, 4, counter)
+}
+
+func TestClient_Do_Retry_NotRetryable(t *testing.T) {
+	client, mux := setup(t)
+	require.NoError(t, WithRetryPolicy(testRetryPolicy)(client))
+
+	var counter int
+	mux.HandleFunc("/api/v1/test", func(w http.ResponseWriter, r *http.Request) {
+		counter++
+		switch r.Method {
+		case http.MethodGet:
+			w.WriteHeader(http.StatusNotFound)
+		case http.MethodPost:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+
+	// 404s are not retried
+	req, err := client.NewRequest(http.MethodGet, "api/v1/test", nil)
+	require.NoError(t, err)
+
+	_, err = client.Do(ctx, req, nil)
+	require.IsType(t, &ErrorResponse{}, err)
+	require.Equal(t, 1, counter)
+
+	// POST requests are not retried by default
+	req, err = client.NewRequest(http.MethodPost, "api/v1/test", url.Values{})
+	require.NoError(t, err)
+
+	_, err = client.Do(ctx, req, nil)
+	require.IsType(t, &ErrorResponse{}, err)
+	require.Equal(t, 2, counter)
+}
+
+func TestClient_Do_Retry_RewindsBody(t *testing.T) {
+	client, mux := setup(t)
+
+	policy := testRetryPolicy
+	policy.RetryNonIdempotent = true
+	require.NoError(t, WithRetryPolicy(policy)(client))
+
+	form := url.Values{}
+	form.Set("id", "t3_test")
+
+	var counter int
+	mux.HandleFunc("/api/v1/test", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		defer func() { counter++ }()
+
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, form, r.PostForm)
+
+		if counter == 0 {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	})
+
+	req, err := client.NewRequest(http.MethodPost, "api/v1/test", form)
+	require.NoError(t, err)
+
+	_, err = client.Do(ctx, req, nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, counter)
+}
+
+func TestClient_Do_Retry_RespectsDeadline(t *testing.T) {
+	client, mux := setup(t)
+	require.NoError(t, WithRetryPolicy(testRetryPolicy)(client))
+
+	var counter int
+	mux.HandleFunc("/api/v1/test", func(w http.ResponseWriter, r *http.Request) {
+		counter++
+		w.Header().Set(headerRetryAfter, "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	req, err := client.NewRequest(http.MethodGet, "api/v1/test", nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	start := time.Now()
+	resp, err := client.Do(ctx, req, nil)
+	require.IsType(t, &ErrorResponse{}, err)
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	require.Equal(t, 1, counter)
+	require.True(t, time.Since(start) < time.Second)
+}
+
+func TestClient_Do_Retry_RateLimitReset(t *testing.T) {
+	client, mux := setup(t)
+	require.NoError(t, WithRetryPolicy(testRetryPolicy)(client))
+
+	var counter int
+	mux.HandleFunc("/api/v1/test", func(w http.ResponseWriter, r *http.Request) {
+		counter++
+	})
+
+	req, err := client.NewRequest(http.MethodGet, "api/v1/test", nil)
+	require.NoError(t, err)
+
+	client.rate.Remaining = 0
+	client.rate.Reset = time.Now().Add(time.Millisecond * 50)
+
+	resp, err := client.Do(ctx, req, nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, 1, counter)
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := &RetryPolicy{MinBackoff: time.Second, MaxBackoff: time.Second * 10}
+	resp := &Response{Response: &http.Response{StatusCode: http.StatusBadGateway, Header: http.Header{}}}
+
+	for attempt, max := range []time.Duration{time.Second, time.Second * 2, time.Second * 4, time.Second * 8, time.Second * 10, time.Second * 10} {
+		d := policy.backoff(attempt, resp)
+		require.True(t, d >= max/2 && d <= max, "attempt %d: %s not in [%s, %s]", attempt, d, max/2, max)
+	}
+
+	resp.Header.Set(headerRetryAfter, "120")
+	require.Equal(t, time.Minute*2, policy.backoff(0, resp))
+
+	resp.Header.Set(headerRetryAfter, "invalid")
+	resp.StatusCode = http.StatusTooManyRequests
+	resp.Rate.Reset = time.Now().Add(time.Minute * 5)
+	d := policy.backoff(0, resp)
+	require.True(t, d > time.Minute*4 && d <= time.Minute*5)
+}
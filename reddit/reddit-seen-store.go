@@ -0,0 +1,293 @@
+package reddit
+
+import (
+	"bufio"
+	"container/list"
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// SeenStore keeps track of the full IDs of the items a stream has sent, so that it
+// only sends each of them once (see StreamSeenStore).
+// A store can be shared by streams whose items can't have the same IDs.
+// Implementations must be safe for concurrent use.
+type SeenStore interface {
+	// Seen reports whether the ID was added to the store.
+	Seen(ctx context.Context, id string) (bool, error)
+	// Add adds the ID to the store.
+	Add(ctx context.Context, id string) error
+}
+
+// minSeenStoreEntries is the minimum number of IDs held by a store with a limit: the number of
+// items Reddit returns per request, so that the items of a listing don't evict each other.
+const minSeenStoreEntries = 100
+
+// MemorySeenStore is an in-memory SeenStore that forgets the least recently seen IDs
+// once it holds a maximum number of them, and the IDs not seen for longer than a time window.
+type MemorySeenStore struct {
+	mu         sync.Mutex
+	maxEntries int
+	window     time.Duration
+	entries    map[string]*list.Element
+	order      *list.List
+}
+
+type seenEntry struct {
+	id    string
+	added time.Time
+}
+
+// NewMemorySeenStore returns a new MemorySeenStore holding up to maxEntries IDs, each of them until
+// it hasn't been seen for the duration of the window. If maxEntries is 0, there is no limit, and if
+// the window is 0, IDs are kept until evicted.
+//
+// Since Reddit returns up to 100 items per request, maxEntries is raised to 100 if it's lower, and
+// a few hundred IDs are usually enough to stream a listing. As a stream checks the items of every
+// request it makes, an item isn't forgotten while it's still in the listing.
+func NewMemorySeenStore(maxEntries int, window time.Duration) *MemorySeenStore {
+	if maxEntries > 0 && maxEntries < minSeenStoreEntries {
+		maxEntries = minSeenStoreEntries
+	}
+	return &MemorySeenStore{
+		maxEntries: maxEntries,
+		window:     window,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// Seen reports whether the ID was added to the store, and hasn't been forgotten since.
+// The time window of the ID starts over.
+func (s *MemorySeenStore) Seen(_ context.Context, id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[id]
+	if !ok {
+		return false, nil
+	}
+	if s.expired(e) {
+		s.remove(e)
+		return false, nil
+	}
+	e.Value.(*seenEntry).added = time.Now()
+	s.order.MoveToFront(e)
+	return true, nil
+}
+
+// Add adds the ID to the store, evicting the least recently seen ID if the store is full.
+func (s *MemorySeenStore) Add(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[id]; ok {
+		e.Value.(*seenEntry).added = time.Now()
+		s.order.MoveToFront(e)
+		return nil
+	}
+
+	s.entries[id] = s.order.PushFront(&seenEntry{id: id, added: time.Now()})
+	for oldest := s.order.Back(); oldest != nil; oldest = s.order.Back() {
+		if !s.expired(oldest) && (s.maxEntries <= 0 || s.order.Len() <= s.maxEntries) {
+			break
+		}
+		s.remove(oldest)
+	}
+	return nil
+}
+
+func (s *MemorySeenStore) expired(e *list.Element) bool {
+	return s.window > 0 && time.Since(e.Value.(*seenEntry).added) > s.window
+}
+
+func (s *MemorySeenStore) remove(e *list.Element) {
+	s.order.Remove(e)
+	delete(s.entries, e.Value.(*seenEntry).id)
+}
+
+// ids returns the IDs of the store, from the least to the most recently seen.
+func (s *MemorySeenStore) ids() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]string, 0, s.order.Len())
+	for e := s.order.Back(); e != nil; e = e.Prev() {
+		ids = append(ids, e.Value.(*seenEntry).id)
+	}
+	return ids
+}
+
+// FileSeenStore is a SeenStore backed by a file, so that streams resume where they left off after a restart.
+// It holds its IDs in memory like a MemorySeenStore without a time window, and appends the ones added to it
+// to the file, one per line. Once the file holds twice as many IDs as the store, and when the store is closed,
+// it's rewritten with the IDs of the store only, from the least to the most recently seen, so that the IDs of
+// items still in a listing aren't evicted first after a restart.
+//
+// Since IDs are added once their items have been received from a stream, an item being processed when the
+// program stops is sent again after the restart.
+type FileSeenStore struct {
+	mu         sync.Mutex
+	path       string
+	file       *os.File
+	lines      int
+	maxEntries int
+	memory     *MemorySeenStore
+}
+
+// NewFileSeenStore returns a new FileSeenStore holding up to maxEntries IDs, loading the ones
+// already in the file at the path, if any. If maxEntries is 0, there is no limit, and the file
+// is never rewritten. Like with NewMemorySeenStore, maxEntries is raised to 100 if it's lower.
+// The store must be closed once it's no longer used.
+func NewFileSeenStore(path string, maxEntries int) (*FileSeenStore, error) {
+	if maxEntries > 0 && maxEntries < minSeenStoreEntries {
+		maxEntries = minSeenStoreEntries
+	}
+	s := &FileSeenStore{
+		path:       path,
+		maxEntries: maxEntries,
+		memory:     NewMemorySeenStore(maxEntries, 0),
+	}
+
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	if maxEntries > 0 && s.lines > maxEntries {
+		if err := s.compact(); err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	s.file = file
+	return s, nil
+}
+
+func (s *FileSeenStore) load() error {
+	file, err := os.Open(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if id := scanner.Text(); id != "" {
+			s.memory.Add(context.Background(), id)
+			s.lines++
+		}
+	}
+	return scanner.Err()
+}
+
+// Seen reports whether the ID was added to the store, and hasn't been evicted since.
+func (s *FileSeenStore) Seen(ctx context.Context, id string) (bool, error) {
+	return s.memory.Seen(ctx, id)
+}
+
+// Add adds the ID to the store and appends it to the file.
+func (s *FileSeenStore) Add(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return errors.New("seen store is closed")
+	}
+
+	if err := s.memory.Add(ctx, id); err != nil {
+		return err
+	}
+
+	if _, err := s.file.WriteString(id + "\n"); err != nil {
+		return err
+	}
+	s.lines++
+
+	if s.maxEntries > 0 && s.lines >= s.maxEntries*2 {
+		return s.compact()
+	}
+	return nil
+}
+
+// compact rewrites the file with the IDs of the store.
+// The file is replaced atomically, so that a crash never leaves a partially written one behind.
+func (s *FileSeenStore) compact() error {
+	ids := s.memory.ids()
+
+	dir, name := filepath.Split(s.path)
+	if dir == "" {
+		dir = "."
+	}
+
+	file, err := ioutil.TempFile(dir, name+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	w := bufio.NewWriter(file)
+	for _, id := range ids {
+		w.WriteString(id + "\n")
+	}
+	if err := w.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Chmod(0644); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	if s.file != nil {
+		s.file.Close()
+		s.file = nil
+	}
+	if err := os.Rename(file.Name(), s.path); err != nil {
+		return err
+	}
+
+	file, err = os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	s.file = file
+	s.lines = len(ids)
+	return nil
+}
+
+// Close rewrites the file with the IDs of the store, unless the store has no limit, and closes it.
+func (s *FileSeenStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+
+	var err error
+	if s.maxEntries > 0 {
+		err = s.compact()
+	}
+	if s.file != nil {
+		if closeErr := s.file.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	s.file = nil
+	return err
+}
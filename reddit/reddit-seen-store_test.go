@@ -0,0 +1,193 @@
+package reddit
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// seenIDs returns the full IDs of the posts from t3_<from> to t3_<to - 1>.
+func seenIDs(from, to int) []string {
+	var ids []string
+	for i := from; i < to; i++ {
+		ids = append(ids, fmt.Sprintf("t3_%d", i))
+	}
+	return ids
+}
+
+func seenLines(ids []string) string {
+	return strings.Join(ids, "\n") + "\n"
+}
+
+func TestMemorySeenStore(t *testing.T) {
+	store := NewMemorySeenStore(100, 0)
+
+	seen, err := store.Seen(ctx, "t3_0")
+	require.NoError(t, err)
+	require.False(t, seen)
+
+	for _, id := range seenIDs(0, 100) {
+		require.NoError(t, store.Add(ctx, id))
+	}
+
+	// t3_0 is now the most recently seen, so t3_1 is evicted
+	seen, err = store.Seen(ctx, "t3_0")
+	require.NoError(t, err)
+	require.True(t, seen)
+
+	require.NoError(t, store.Add(ctx, "t3_100"))
+	require.Equal(t, append(seenIDs(2, 100), "t3_0", "t3_100"), store.ids())
+
+	seen, err = store.Seen(ctx, "t3_1")
+	require.NoError(t, err)
+	require.False(t, seen)
+}
+
+func TestMemorySeenStore_MaxEntries(t *testing.T) {
+	require.Equal(t, 0, NewMemorySeenStore(0, 0).maxEntries)
+	require.Equal(t, 100, NewMemorySeenStore(2, 0).maxEntries)
+	require.Equal(t, 500, NewMemorySeenStore(500, 0).maxEntries)
+
+	// the items of a listing don't evict each other
+	store := NewMemorySeenStore(2, 0)
+	for _, id := range seenIDs(0, 100) {
+		require.NoError(t, store.Add(ctx, id))
+	}
+	require.Equal(t, seenIDs(0, 100), store.ids())
+}
+
+func TestMemorySeenStore_Window(t *testing.T) {
+	store := NewMemorySeenStore(0, time.Millisecond*20)
+
+	require.NoError(t, store.Add(ctx, "t3_1"))
+	seen, err := store.Seen(ctx, "t3_1")
+	require.NoError(t, err)
+	require.True(t, seen)
+
+	time.Sleep(time.Millisecond * 30)
+	require.NoError(t, store.Add(ctx, "t3_2"))
+
+	seen, err = store.Seen(ctx, "t3_1")
+	require.NoError(t, err)
+	require.False(t, seen)
+	require.Equal(t, []string{"t3_2"}, store.ids())
+}
+
+func TestMemorySeenStore_Window_Seen(t *testing.T) {
+	store := NewMemorySeenStore(0, time.Millisecond*100)
+
+	require.NoError(t, store.Add(ctx, "t3_1"))
+	require.NoError(t, store.Add(ctx, "t3_2"))
+
+	time.Sleep(time.Millisecond * 60)
+	seen, err := store.Seen(ctx, "t3_1")
+	require.NoError(t, err)
+	require.True(t, seen)
+
+	// the window of t3_1 started over when it was seen
+	time.Sleep(time.Millisecond * 60)
+	seen, err = store.Seen(ctx, "t3_1")
+	require.NoError(t, err)
+	require.True(t, seen)
+
+	seen, err = store.Seen(ctx, "t3_2")
+	require.NoError(t, err)
+	require.False(t, seen)
+}
+
+func TestFileSeenStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seen")
+
+	store, err := NewFileSeenStore(path, 100)
+	require.NoError(t, err)
+	require.NoError(t, store.Add(ctx, "t3_0"))
+	require.NoError(t, store.Add(ctx, "t3_1"))
+	require.NoError(t, store.Close())
+
+	require.EqualError(t, store.Add(ctx, "t3_2"), "seen store is closed")
+
+	store, err = NewFileSeenStore(path, 100)
+	require.NoError(t, err)
+	defer store.Close()
+
+	seen, err := store.Seen(ctx, "t3_1")
+	require.NoError(t, err)
+	require.True(t, seen)
+
+	for _, id := range seenIDs(2, 199) {
+		require.NoError(t, store.Add(ctx, id))
+	}
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, seenLines(seenIDs(0, 199)), string(data))
+
+	// the file holds twice as many IDs as the store, so it's rewritten
+	require.NoError(t, store.Add(ctx, "t3_199"))
+	data, err = ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, seenLines(seenIDs(100, 200)), string(data))
+
+	seen, err = store.Seen(ctx, "t3_0")
+	require.NoError(t, err)
+	require.False(t, seen)
+}
+
+func TestFileSeenStore_Compact(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seen")
+	require.NoError(t, ioutil.WriteFile(path, []byte(seenLines(seenIDs(0, 101))), 0644))
+
+	// the store holds at least 100 IDs
+	store, err := NewFileSeenStore(path, 2)
+	require.NoError(t, err)
+	defer store.Close()
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, seenLines(seenIDs(1, 101)), string(data))
+
+	require.NoError(t, store.Add(ctx, "t3_101"))
+	data, err = ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, seenLines(seenIDs(1, 102)), string(data))
+}
+
+func TestFileSeenStore_Restart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seen")
+
+	store, err := NewFileSeenStore(path, 100)
+	require.NoError(t, err)
+	for _, id := range seenIDs(0, 100) {
+		require.NoError(t, store.Add(ctx, id))
+	}
+
+	// t3_0 is still in the listing, so it's seen again
+	seen, err := store.Seen(ctx, "t3_0")
+	require.NoError(t, err)
+	require.True(t, seen)
+	require.NoError(t, store.Close())
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, seenLines(append(seenIDs(1, 100), "t3_0")), string(data))
+
+	// after a restart, the least recently seen ID is evicted first
+	store, err = NewFileSeenStore(path, 100)
+	require.NoError(t, err)
+	defer store.Close()
+
+	require.NoError(t, store.Add(ctx, "t3_100"))
+
+	seen, err = store.Seen(ctx, "t3_0")
+	require.NoError(t, err)
+	require.True(t, seen)
+
+	seen, err = store.Seen(ctx, "t3_1")
+	require.NoError(t, err)
+	require.False(t, seen)
+}
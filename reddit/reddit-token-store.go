@@ -0,0 +1,178 @@
+package reddit
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+// TokenStore persists the client's access token, so that it can be reused across processes
+// instead of requesting a new one every time. The client loads the token from the store when
+// it is initialized, and saves it again every time a new one is obtained.
+// Implementations must be safe for concurrent use.
+type TokenStore interface {
+	// Load returns the stored token, or nil if there isn't one.
+	Load(ctx context.Context) (*oauth2.Token, error)
+	// Save stores the token, replacing the previous one.
+	Save(ctx context.Context, token *oauth2.Token) error
+	// Delete removes the stored token, if any.
+	Delete(ctx context.Context) error
+}
+
+// MemoryTokenStore is an in-memory TokenStore.
+// It can be used to share a token between clients in the same process.
+// The zero value is ready to use.
+type MemoryTokenStore struct {
+	mu    sync.Mutex
+	token *oauth2.Token
+}
+
+// NewMemoryTokenStore returns a new MemoryTokenStore.
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return new(MemoryTokenStore)
+}
+
+// Load returns the stored token, or nil if there isn't one.
+func (s *MemoryTokenStore) Load(_ context.Context) (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.token, nil
+}
+
+// Save stores the token, replacing the previous one.
+func (s *MemoryTokenStore) Save(_ context.Context, token *oauth2.Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = token
+	return nil
+}
+
+// Delete removes the stored token, if any.
+func (s *MemoryTokenStore) Delete(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = nil
+	return nil
+}
+
+// FileTokenStore is a TokenStore that keeps the token as JSON in a file.
+// The file is only readable and writable by its owner, since it contains credentials.
+type FileTokenStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileTokenStore returns a new FileTokenStore that keeps the token in the file at the path.
+// The file is created when the first token is saved.
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{path: path}
+}
+
+// Load returns the stored token, or nil if there isn't one.
+func (s *FileTokenStore) Load(_ context.Context) (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	token := new(oauth2.Token)
+	if err := json.Unmarshal(data, token); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// Save stores the token, replacing the previous one.
+// The file is replaced atomically, so that a crash never leaves a partially written token behind.
+func (s *FileTokenStore) Save(_ context.Context, token *oauth2.Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	dir, name := filepath.Split(s.path)
+	if dir == "" {
+		dir = "."
+	}
+
+	file, err := ioutil.TempFile(dir, name+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Chmod(0600); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), s.path)
+}
+
+// Delete removes the stored token, if any.
+func (s *FileTokenStore) Delete(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := os.Remove(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// storingTokenSource returns the token from the store until it expires, and then
+// gets a new one from its base token source, saving it to the store.
+type storingTokenSource struct {
+	ctx   context.Context
+	store TokenStore
+	base  oauth2.TokenSource
+
+	mu    sync.Mutex
+	token *oauth2.Token
+}
+
+func (s *storingTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token.Valid() {
+		return s.token, nil
+	}
+
+	token, err := s.base.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	if token != s.token {
+		if err := s.store.Save(s.ctx, token); err != nil {
+			return nil, err
+		}
+		s.token = token
+	}
+
+	return token, nil
+}
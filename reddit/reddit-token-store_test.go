@@ -0,0 +1,147 @@
+package reddit
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestMemoryTokenStore(t *testing.T) {
+	store := NewMemoryTokenStore()
+
+	token, err := store.Load(ctx)
+	require.NoError(t, err)
+	require.Nil(t, token)
+
+	expected := &oauth2.Token{AccessToken: "token1"}
+	require.NoError(t, store.Save(ctx, expected))
+
+	token, err = store.Load(ctx)
+	require.NoError(t, err)
+	require.Equal(t, expected, token)
+
+	require.NoError(t, store.Delete(ctx))
+
+	token, err = store.Load(ctx)
+	require.NoError(t, err)
+	require.Nil(t, token)
+}
+
+func TestFileTokenStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token.json")
+	store := NewFileTokenStore(path)
+
+	token, err := store.Load(ctx)
+	require.NoError(t, err)
+	require.Nil(t, token)
+
+	expected := &oauth2.Token{
+		AccessToken:  "token1",
+		TokenType:    "bearer",
+		RefreshToken: "refresh1",
+		Expiry:       time.Date(2021, 1, 31, 12, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, store.Save(ctx, expected))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// a new store reading the same file, e.g. after a restart
+	token, err = NewFileTokenStore(path).Load(ctx)
+	require.NoError(t, err)
+	require.Equal(t, expected, token)
+
+	require.NoError(t, store.Delete(ctx))
+	require.NoError(t, store.Delete(ctx))
+
+	token, err = store.Load(ctx)
+	require.NoError(t, err)
+	require.Nil(t, token)
+}
+
+func TestFileTokenStore_Error(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte("not json"), 0600))
+
+	_, err := NewClient(Credentials{}, WithTokenStore(NewFileTokenStore(path)))
+	require.Error(t, err)
+}
+
+func TestClient_TokenStore(t *testing.T) {
+	client, mux := setup(t)
+
+	var tokenRequests int
+	mux.HandleFunc("/api/v1/access_token2", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		tokenRequests++
+
+		w.Header().Add(headerContentType, mediaTypeJSON)
+		fmt.Fprintf(w, `{
+			"access_token": "token%d",
+			"token_type": "bearer",
+			"expires_in": 3600,
+			"scope": "*"
+		}`, tokenRequests+1)
+	})
+
+	var tokens []string
+	mux.HandleFunc("/api/v1/test", func(w http.ResponseWriter, r *http.Request) {
+		tokens = append(tokens, r.Header.Get("Authorization"))
+	})
+
+	store := NewMemoryTokenStore()
+	require.NoError(t, store.Save(ctx, &oauth2.Token{
+		AccessToken: "token1",
+		TokenType:   "bearer",
+		Expiry:      time.Now().Add(time.Hour),
+	}))
+
+	newClient := func() *Client {
+		c, err := NewClient(
+			Credentials{"id1", "secret1", "user1", "password1"},
+			WithBaseURL(client.BaseURL.String()),
+			WithTokenURL(client.BaseURL.String()+"/api/v1/access_token2"),
+			WithTokenStore(store),
+		)
+		require.NoError(t, err)
+		return c
+	}
+
+	req, err := client.NewRequest(http.MethodGet, "api/v1/test", nil)
+	require.NoError(t, err)
+
+	// the stored token is still valid, so no new one is requested
+	_, err = newClient().Do(ctx, req, nil)
+	require.NoError(t, err)
+	require.Equal(t, 0, tokenRequests)
+
+	// once the stored token expires, a new one is requested and saved
+	require.NoError(t, store.Save(ctx, &oauth2.Token{
+		AccessToken: "token1",
+		TokenType:   "bearer",
+		Expiry:      time.Now().Add(-time.Minute),
+	}))
+
+	_, err = newClient().Do(ctx, req, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, tokenRequests)
+
+	token, err := store.Load(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "token2", token.AccessToken)
+
+	// the next process reuses the saved token
+	_, err = newClient().Do(ctx, req, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, tokenRequests)
+
+	require.Equal(t, []string{"Bearer token1", "Bearer token2", "Bearer token2"}, tokens)
+}
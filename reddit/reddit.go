@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -26,6 +27,7 @@ const (
 	defaultBaseURL         = "https://oauth.reddit.com"
 	defaultBaseURLReadonly = "https://reddit.com"
 	defaultTokenURL        = "https://www.reddit.com/api/v1/access_token"
+	defaultAuthURL         = "https://www.reddit.com/api/v1/authorize"
 
 	mediaTypeJSON = "application/json"
 	mediaTypeForm = "application/x-www-form-urlencoded"
@@ -34,6 +36,8 @@ const (
 	headerAccept      = "Accept"
 	headerUserAgent   = "User-Agent"
 
+	headerWWWAuthenticate = "WWW-Authenticate"
+
 	headerRateLimitRemaining = "x-ratelimit-remaining"
 	headerRateLimitUsed      = "x-ratelimit-used"
 	headerRateLimitReset     = "x-ratelimit-reset"
@@ -69,6 +73,8 @@ type Client struct {
 
 	rateMu sync.Mutex
 	rate   Rate
+	// If set, the rate limit state is shared with other clients through it.
+	rateBudget RateBudget
 
 	ID       string
 	Secret   string
@@ -90,6 +96,7 @@ type Client struct {
 	Moderation *ModerationService
 	Multi      *MultiService
 	Post       *PostService
+	Scopes     *ScopesService
 	Stream     *StreamService
 	Subreddit  *SubredditService
 	User       *UserService
@@ -97,8 +104,34 @@ type Client struct {
 	Wiki       *WikiService
 
 	oauth2Transport *oauth2.Transport
+	// If set, these are used to authenticate instead of the username and password.
+	token       *oauth2.Token
+	tokenSource oauth2.TokenSource
+	// If true, the client authenticates as the app itself instead of as a user.
+	applicationOnly bool
+	// The device ID used to authenticate installed apps as themselves.
+	deviceID string
+	// If set, the client's token is loaded from and saved to it.
+	tokenStore TokenStore
+
+	scopesMu sync.Mutex
+	// The scopes granted to the client's access token, if known.
+	scopes []string
 
 	onRequestCompleted RequestCompletionCallback
+
+	retryPolicy *RetryPolicy
+	rateLimiter RateLimiter
+	middleware  []Middleware
+
+	// If set, responses to GET requests are cached in it.
+	cache     Cache
+	cacheTTLs map[CacheClass]time.Duration
+
+	observers []Observer
+
+	// Maximum number of requests ListingsService methods make at the same time.
+	listingsConcurrency int
 }
 
 // OnRequestCompleted sets the client's request completion callback.
@@ -122,15 +155,15 @@ func newClient() *Client {
 	client.Message = &MessageService{client: client}
 	client.Moderation = &ModerationService{client: client}
 	client.Multi = &MultiService{client: client}
+	client.Scopes = &ScopesService{client: client}
 	client.Stream = &StreamService{client: client}
 	client.Subreddit = &SubredditService{client: client}
 	client.User = &UserService{client: client}
 	client.Widget = &WidgetService{client: client}
 	client.Wiki = &WikiService{client: client}
 
-	postAndCommentService := &postAndCommentService{client: client}
-	client.Comment = &CommentService{client: client, postAndCommentService: postAndCommentService}
-	client.Post = &PostService{client: client, postAndCommentService: postAndCommentService}
+	client.Comment = &CommentService{client: client, postAndCommentService: &postAndCommentService{client: client, service: "Comment", kind: kindComment}}
+	client.Post = &PostService{client: client, postAndCommentService: &postAndCommentService{client: client, service: "Post", kind: kindPost}}
 
 	return client
 }
@@ -147,6 +180,73 @@ func NewClient(credentials Credentials, opts ...Opt) (*Client, error) {
 	client.Username = credentials.Username
 	client.Password = credentials.Password
 
+	return newOAuthClient(client, opts...)
+}
+
+// NewClientWithToken returns a new Reddit API client that acts on behalf of the user who
+// granted the token, e.g. one obtained via ExchangeCode or stored from a previous session.
+// Once the token expires, it is refreshed automatically using its refresh token.
+// Only the ID and Secret of the credentials are used for refreshing the token; set the Username
+// as well if you plan on calling methods that act on the current user by name, e.g. UserService.Overview.
+func NewClientWithToken(credentials Credentials, token *oauth2.Token, opts ...Opt) (*Client, error) {
+	if token == nil {
+		return nil, errors.New("*oauth2.Token: cannot be nil")
+	}
+
+	client := newClient()
+	client.ID = credentials.ID
+	client.Secret = credentials.Secret
+	client.Username = credentials.Username
+	client.token = token
+
+	return newOAuthClient(client, opts...)
+}
+
+// NewClientWithTokenSource returns a new Reddit API client that authenticates with the access
+// tokens provided by the token source. The token source is responsible for refreshing the tokens.
+func NewClientWithTokenSource(tokenSource oauth2.TokenSource, opts ...Opt) (*Client, error) {
+	if tokenSource == nil {
+		return nil, errors.New("oauth2.TokenSource: cannot be nil")
+	}
+
+	client := newClient()
+	client.tokenSource = tokenSource
+
+	return newOAuthClient(client, opts...)
+}
+
+// NewApplicationClient returns a new Reddit API client that authenticates as your app itself
+// instead of as one of its users (application-only OAuth, via the client_credentials grant).
+// It has the same rate limits as a client authenticated as a user, but can only access public data.
+// It requires the ID and Secret of a web or script app; the Username and Password are not used.
+func NewApplicationClient(credentials Credentials, opts ...Opt) (*Client, error) {
+	client := newClient()
+	client.ID = credentials.ID
+	client.Secret = credentials.Secret
+	client.applicationOnly = true
+
+	return newOAuthClient(client, opts...)
+}
+
+// NewInstalledApplicationClient returns a new Reddit API client that authenticates an installed app
+// (which has no secret) as itself, instead of as one of its users (application-only OAuth).
+// The deviceID should be a unique ID of 20-30 characters for the device the app is installed on.
+// If empty, Reddit's "DO_NOT_TRACK_THIS_DEVICE" is used.
+func NewInstalledApplicationClient(clientID, deviceID string, opts ...Opt) (*Client, error) {
+	if deviceID == "" {
+		deviceID = defaultDeviceID
+	}
+
+	client := newClient()
+	client.ID = clientID
+	client.applicationOnly = true
+	client.deviceID = deviceID
+
+	return newOAuthClient(client, opts...)
+}
+
+// newOAuthClient configures the client with the options and sets up its OAuth2 transport.
+func newOAuthClient(client *Client, opts ...Opt) (*Client, error) {
 	for _, opt := range opts {
 		if err := opt(client); err != nil {
 			return nil, err
@@ -163,7 +263,11 @@ func NewClient(credentials Credentials, opts ...Opt) (*Client, error) {
 		client.client.CheckRedirect = client.redirect
 	}
 
-	oauthTransport := oauthTransport(client)
+	oauthTransport, err := oauthTransport(client)
+	if err != nil {
+		return nil, err
+	}
+	client.oauth2Transport = oauthTransport
 	client.client.Transport = oauthTransport
 
 	return client, nil
@@ -300,9 +404,24 @@ type Response struct {
 
 	// Pagination anchor indicating there are more results after this id.
 	After string
+	// Pagination anchor indicating there are more results before this id.
+	// Reddit generally only includes it if the request had a Count (see ListOptions).
+	Before string
+	// Number of items in the listing returned.
+	Dist int
+	// Number of items of the listing seen so far, i.e. the count of the request plus Dist.
+	// Pass it as the Count of the ListOptions when requesting the next page.
+	Count int
+	// Token Reddit includes in some listings to protect cookie-authenticated requests
+	// against CSRF. It isn't needed for requests made with OAuth2.
+	Modhash string
 
 	// Rate limit information.
 	Rate Rate
+	// Whether the response was served from the client's cache, without making a request (see WithCache).
+	Cached bool
+	// Full IDs requested via ListingsService that weren't returned, e.g. because they were deleted or are inaccessible.
+	Missing []string
 }
 
 // newResponse creates a new Response for the provided http.Response.
@@ -314,6 +433,15 @@ func newResponse(r *http.Response) *Response {
 
 func (r *Response) populateAnchors(a anchor) {
 	r.After = a.After()
+	r.Before = a.Before()
+	r.Dist = a.Dist()
+	r.Modhash = a.Modhash()
+
+	r.Count = r.Dist
+	if r.Response != nil && r.Request != nil {
+		count, _ := strconv.Atoi(r.Request.URL.Query().Get("count"))
+		r.Count += count
+	}
 }
 
 // parseRate parses the rate related headers.
@@ -337,8 +465,48 @@ func parseRate(r *http.Response) Rate {
 // Do sends an API request and returns the API response. The API response is JSON decoded and stored in the value
 // pointed to by v, or returned as an error if an API error has occurred. If v implements the io.Writer interface,
 // the raw response will be written to v, without attempting to decode it.
+// If the client was configured with a retry policy (see WithRetryPolicy), failed requests may be retried.
+// If the client was configured with middleware (see WithMiddleware), it wraps the whole process.
 func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
-	if err := c.checkRateLimitBeforeDo(req); err != nil {
+	if len(c.observers) > 0 && operationFromContext(ctx) == "" {
+		ctx = withOperation(ctx, callerOperation())
+	}
+	return c.handler()(ctx, req, v)
+}
+
+// do sends the request once.
+func (c *Client) do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+	rate, err := c.getRate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheKey, cacheTTL := c.cacheKey(req)
+	var cached *CachedResponse
+	if cacheKey != "" {
+		cached, err = c.cache.Get(ctx, cacheKey)
+		if err != nil {
+			return nil, err
+		}
+
+		if cached != nil && cached.fresh() {
+			response := &Response{Response: cached.response(req), Rate: rate, Cached: true}
+			return response, decodeResponse(response, v)
+		}
+		if cached != nil && cached.revalidatable() {
+			req = conditionalRequest(req, cached)
+		} else {
+			cached = nil
+		}
+	}
+
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.Wait(ctx, rate); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.checkRateLimitBeforeDo(req, rate); err != nil {
 		return &Response{
 			Response: err.Response,
 			Rate:     err.Rate,
@@ -357,41 +525,82 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Res
 
 	response := newResponse(resp)
 
-	c.rateMu.Lock()
-	c.rate = response.Rate
-	c.rateMu.Unlock()
+	if err := c.setRate(ctx, response.Rate); err != nil {
+		return response, err
+	}
 
-	err = CheckResponse(resp)
-	if err != nil {
+	if err := c.invalidateCache(ctx, req); err != nil {
 		return response, err
 	}
 
-	if v != nil {
-		if w, ok := v.(io.Writer); ok {
-			_, err = io.Copy(w, response.Body)
-			if err != nil {
-				return response, err
-			}
-		} else {
-			err = json.NewDecoder(response.Body).Decode(v)
-			if err != nil {
-				return response, err
-			}
+	if cached != nil && resp.StatusCode == http.StatusNotModified {
+		if err := c.revalidateCachedResponse(ctx, cacheKey, cacheTTL, cached); err != nil {
+			return response, err
+		}
+		response.Response = cached.response(req)
+	} else if cacheKey != "" && resp.StatusCode == http.StatusOK {
+		if err := c.cacheResponse(ctx, cacheKey, cacheTTL, resp); err != nil {
+			return response, err
 		}
+	}
 
-		if anchor, ok := v.(anchor); ok {
-			response.populateAnchors(anchor)
+	err = CheckResponse(response.Response)
+	if err != nil {
+		if scopeErr, ok := err.(*InsufficientScopeError); ok {
+			scopeErr.Granted = c.GrantedScopes()
 		}
+		return response, err
 	}
 
-	return response, nil
+	return response, decodeResponse(response, v)
 }
 
-func (c *Client) checkRateLimitBeforeDo(req *http.Request) *RateLimitError {
+// decodeResponse decodes the body of the response into v, if provided.
+func decodeResponse(response *Response, v interface{}) error {
+	if v == nil {
+		return nil
+	}
+
+	if w, ok := v.(io.Writer); ok {
+		if _, err := io.Copy(w, response.Body); err != nil {
+			return err
+		}
+	} else {
+		if err := json.NewDecoder(response.Body).Decode(v); err != nil {
+			return err
+		}
+	}
+
+	if anchor, ok := v.(anchor); ok {
+		response.populateAnchors(anchor)
+	}
+	return nil
+}
+
+// getRate returns the last known rate limit for the client.
+func (c *Client) getRate(ctx context.Context) (Rate, error) {
+	if c.rateBudget != nil {
+		return c.rateBudget.Rate(ctx)
+	}
+
 	c.rateMu.Lock()
-	rate := c.rate
+	defer c.rateMu.Unlock()
+	return c.rate, nil
+}
+
+// setRate records the rate limit of the latest response.
+func (c *Client) setRate(ctx context.Context, rate Rate) error {
+	if c.rateBudget != nil {
+		return c.rateBudget.Update(ctx, rate)
+	}
+
+	c.rateMu.Lock()
+	c.rate = rate
 	c.rateMu.Unlock()
+	return nil
+}
 
+func (c *Client) checkRateLimitBeforeDo(req *http.Request, rate Rate) *RateLimitError {
 	if !rate.Reset.IsZero() && rate.Remaining == 0 && time.Now().Before(rate.Reset) {
 		// Create a fake 429 response.
 		resp := &http.Response{
@@ -422,7 +631,7 @@ func (c *Client) id(ctx context.Context) (string, *Response, error) {
 		return "", resp, err
 	}
 
-	c.redditID = fmt.Sprintf("%s_%s", kindUser, self.ID)
+	c.redditID = string(NewFullname(kindUser, self.ID))
 	return c.redditID, resp, nil
 }
 
@@ -440,6 +649,9 @@ func DoRequestWithClient(ctx context.Context, client *http.Client, req *http.Req
 // CheckResponse checks the API response for errors, and returns them if present.
 // A response is considered an error if it has a status code outside the 200 range.
 // Reddit also sometimes sends errors with 200 codes; we check for those too.
+// The returned errors can be inspected with errors.Is using sentinel errors such as ErrNotFound.
+// A RATELIMIT error sent with a 200 code is returned as a *SubmitRateLimitError, which wraps the
+// *JSONErrorResponse previously returned for it: use errors.As instead of asserting the error's type.
 func CheckResponse(r *http.Response) error {
 	if r.Header.Get(headerRateLimitRemaining) == "0" {
 		err := &RateLimitError{
@@ -456,6 +668,9 @@ func CheckResponse(r *http.Response) error {
 	if err == nil && len(data) > 0 {
 		json.Unmarshal(data, jsonErrorResponse)
 		if len(jsonErrorResponse.JSON.Errors) > 0 {
+			if submitErr, ok := newSubmitRateLimitError(jsonErrorResponse); ok {
+				return submitErr
+			}
 			return jsonErrorResponse
 		}
 	}
@@ -467,6 +682,10 @@ func CheckResponse(r *http.Response) error {
 		return nil
 	}
 
+	if scope, ok := insufficientScope(r); ok {
+		return &InsufficientScopeError{Response: r, Scope: scope}
+	}
+
 	errorResponse := &ErrorResponse{Response: r}
 	data, err = ioutil.ReadAll(r.Body)
 	if err == nil && len(data) > 0 {
@@ -535,6 +754,11 @@ type ListOptions struct {
 	// as the anchor point of the list. Only items
 	// appearing before it will be returned.
 	Before string `url:"before,omitempty"`
+
+	// The number of items of the listing already seen.
+	// Reddit uses it to number the items, and only includes the Before
+	// anchor in a response if it's set (see Response.Count).
+	Count int `url:"count,omitempty"`
 }
 
 // ListSubredditOptions defines possible options used when searching for subreddits.
@@ -7,8 +7,10 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -58,6 +60,15 @@ func readFileContents(path string) (string, error) {
 	return string(bytes), err
 }
 
+// thingListing returns a listing of things with the full IDs, e.g. t3_abc123, in the format of Reddit's responses.
+func thingListing(ids ...string) string {
+	children := make([]string, len(ids))
+	for i, id := range ids {
+		children[i] = fmt.Sprintf(`{"kind": %q, "data": {"name": %q}}`, Fullname(id).Kind(), id)
+	}
+	return fmt.Sprintf(`{"kind": "Listing", "data": {"children": [%s]}}`, strings.Join(children, ","))
+}
+
 func testClientServices(t *testing.T, c *Client) {
 	services := []string{
 		"Account",
@@ -72,6 +83,7 @@ func testClientServices(t *testing.T, c *Client) {
 		"Moderation",
 		"Multi",
 		"Post",
+		"Scopes",
 		"Stream",
 		"Subreddit",
 		"User",
@@ -175,6 +187,49 @@ func TestClient_OnRequestComplemented(t *testing.T) {
 	require.Equal(t, 6, i)
 }
 
+func TestClient_Do_Anchors(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/api/v1/test", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+
+		form := url.Values{}
+		form.Set("after", "t3_1")
+		form.Set("count", "25")
+
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, form, r.Form)
+
+		fmt.Fprint(w, `{
+			"kind": "Listing",
+			"data": {
+				"modhash": "modhash1",
+				"dist": 2,
+				"children": [
+					{"kind": "t3", "data": {"name": "t3_2"}},
+					{"kind": "t3", "data": {"name": "t3_3"}}
+				],
+				"after": "t3_3",
+				"before": "t3_2"
+			}
+		}`)
+	})
+
+	path, err := addOptions("api/v1/test", &ListOptions{After: "t3_1", Count: 25})
+	require.NoError(t, err)
+
+	req, err := client.NewRequest(http.MethodGet, path, nil)
+	require.NoError(t, err)
+
+	resp, err := client.Do(ctx, req, new(thing))
+	require.NoError(t, err)
+	require.Equal(t, "t3_3", resp.After)
+	require.Equal(t, "t3_2", resp.Before)
+	require.Equal(t, 2, resp.Dist)
+	require.Equal(t, 27, resp.Count)
+	require.Equal(t, "modhash1", resp.Modhash)
+}
+
 func TestClient_JSONErrorResponse(t *testing.T) {
 	client, mux := setup(t)
 
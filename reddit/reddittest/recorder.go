@@ -0,0 +1,307 @@
+// Package reddittest provides utilities for testing code that uses the reddit package.
+package reddittest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+)
+
+// Redacted replaces secrets, such as OAuth2 tokens and passwords, in recorded interactions.
+const Redacted = "REDACTED"
+
+// Mode determines whether a Recorder sends requests to the real server or replays recorded responses.
+type Mode int
+
+const (
+	// ModeReplay replays the interactions recorded in the cassette, without making real requests.
+	// Requests that don't match any recorded interaction fail.
+	ModeReplay Mode = iota
+	// ModeRecord makes real requests and records them, replacing the cassette.
+	ModeRecord
+	// ModeAuto replays the cassette if it exists, and records a new one otherwise.
+	ModeAuto
+)
+
+// Headers whose values are redacted when recorded.
+var redactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// Form fields whose values are redacted when recorded.
+var redactedFields = []string{"password", "client_secret", "code", "refresh_token", "access_token", "token"}
+
+// JSON fields whose values are redacted in recorded response bodies.
+var redactedJSONRegex = regexp.MustCompile(`("(?:access_token|refresh_token|id_token)"\s*:\s*)"[^"]*"`)
+
+// Cassette is a list of recorded HTTP interactions.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and the response it got.
+type Interaction struct {
+	Request  *RecordedRequest  `json:"request"`
+	Response *RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a recorded HTTP request.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	// The query parameters and form-encoded body of the request.
+	Form url.Values `json:"form,omitempty"`
+	// The body of the request, if it wasn't form-encoded.
+	Body string `json:"body,omitempty"`
+}
+
+// RecordedResponse is a recorded HTTP response.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that records HTTP interactions to a cassette file,
+// and replays them later without network access. Secrets such as OAuth2 tokens and
+// passwords are redacted from the cassette.
+//
+// Recorded interactions are matched to requests by method, path and form (query
+// parameters and form-encoded body), in the order they were recorded. Each one is
+// replayed at most once, so repeated requests get the responses they got when recorded.
+//
+// Use it with the reddit package via reddit.WithHTTPClient(recorder.Client()).
+type Recorder struct {
+	path string
+	mode Mode
+
+	// Transport used to make real requests when recording.
+	// If nil, http.DefaultTransport is used.
+	Transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette *Cassette
+	replayed []bool
+}
+
+// NewRecorder returns a new Recorder that uses the cassette file at the path.
+// In ModeReplay, the cassette must exist.
+func NewRecorder(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{path: path, mode: mode, cassette: new(Cassette)}
+
+	if mode == ModeAuto {
+		r.mode = ModeReplay
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			r.mode = ModeRecord
+		}
+	}
+
+	if r.mode == ModeReplay {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, r.cassette); err != nil {
+			return nil, fmt.Errorf("reddittest: cannot parse cassette %s: %w", path, err)
+		}
+		r.replayed = make([]bool, len(r.cassette.Interactions))
+	}
+
+	return r, nil
+}
+
+// Mode returns the mode of the recorder. For a recorder created with ModeAuto,
+// it's either ModeReplay or ModeRecord, depending on whether the cassette existed.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Client returns a new *http.Client that sends its requests through the recorder.
+// A new one is returned every time, since the reddit package modifies the client it's given.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, body, err := recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+
+	// the body was consumed to record it
+	if req.Body != nil {
+		req = req.Clone(req.Context())
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	resp, err := r.transport().RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+
+	header := resp.Header.Clone()
+	for _, key := range redactedHeaders {
+		if header.Get(key) != "" {
+			header.Set(key, Redacted)
+		}
+	}
+
+	interaction := &Interaction{
+		Request: recorded,
+		Response: &RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     header,
+			Body:       redactedJSONRegex.ReplaceAllString(string(data), `$1"`+Redacted+`"`),
+		},
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	if err := r.save(); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *Recorder) transport() http.RoundTripper {
+	if r.Transport != nil {
+		return r.Transport
+	}
+	return http.DefaultTransport
+}
+
+// replay returns the response of the first interaction that matches the request and wasn't replayed yet.
+func (r *Recorder) replay(req *http.Request, recorded *RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.replayed[i] || !matches(interaction.Request, recorded) {
+			continue
+		}
+		r.replayed[i] = true
+
+		resp := interaction.Response
+		header := resp.Header.Clone()
+		if header == nil {
+			header = make(http.Header)
+		}
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode)),
+			StatusCode:    resp.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(bytes.NewBufferString(resp.Body)),
+			ContentLength: int64(len(resp.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("reddittest: no recorded interaction matches %s %s", recorded.Method, recorded.URL)
+}
+
+// save writes the cassette to its file.
+func (r *Recorder) save() error {
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, data, 0644)
+}
+
+// recordRequest returns the redacted recording of the request, and its body.
+func recordRequest(req *http.Request) (*RecordedRequest, []byte, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	u := *req.URL
+	form := u.Query()
+	u.RawQuery = ""
+
+	recorded := &RecordedRequest{
+		Method: req.Method,
+		Header: req.Header.Clone(),
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if mediaType == "application/x-www-form-urlencoded" {
+		bodyForm, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, nil, err
+		}
+		for key, values := range bodyForm {
+			form[key] = append(form[key], values...)
+		}
+	} else if len(body) > 0 {
+		recorded.Body = string(body)
+	}
+
+	for _, key := range redactedHeaders {
+		if recorded.Header.Get(key) != "" {
+			recorded.Header.Set(key, Redacted)
+		}
+	}
+	for _, key := range redactedFields {
+		if _, ok := form[key]; ok {
+			form.Set(key, Redacted)
+		}
+	}
+
+	recorded.URL = u.String()
+	if len(form) > 0 {
+		recorded.Form = form
+	}
+
+	return recorded, body, nil
+}
+
+// matches reports whether the recorded request has the same method, path and form as the request.
+func matches(recorded, req *RecordedRequest) bool {
+	if recorded.Method != req.Method {
+		return false
+	}
+
+	u1, err1 := url.Parse(recorded.URL)
+	u2, err2 := url.Parse(req.URL)
+	if err1 != nil || err2 != nil || u1.Path != u2.Path {
+		return false
+	}
+
+	return recorded.Form.Encode() == req.Form.Encode()
+}
@@ -0,0 +1,139 @@
+package reddittest
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vartanbeno/go-reddit/v2/reddit"
+)
+
+var ctx = context.Background()
+
+func newServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/api/v1/access_token", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, `{
+			"access_token": "secret-token",
+			"token_type": "bearer",
+			"expires_in": 3600,
+			"refresh_token": "secret-refresh-token",
+			"scope": "*"
+		}`)
+	})
+
+	var calls int
+	mux.HandleFunc("/r/golang/about", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprintf(w, `{"kind": "t5", "data": {"display_name": "golang", "subscribers": %d}}`, calls)
+	})
+
+	return server
+}
+
+func newClient(t *testing.T, recorder *Recorder, baseURL string) *reddit.Client {
+	client, err := reddit.NewClient(
+		reddit.Credentials{ID: "id1", Secret: "secret1", Username: "user1", Password: "password1"},
+		reddit.WithHTTPClient(recorder.Client()),
+		reddit.WithBaseURL(baseURL),
+		reddit.WithTokenURL(baseURL+"/api/v1/access_token"),
+	)
+	require.NoError(t, err)
+	return client
+}
+
+func TestRecorder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassettes", "subreddit.json")
+	server := newServer(t)
+
+	recorder, err := NewRecorder(path, ModeRecord)
+	require.NoError(t, err)
+	require.Equal(t, ModeRecord, recorder.Mode())
+
+	client := newClient(t, recorder, server.URL)
+	for i := 1; i <= 2; i++ {
+		subreddit, _, err := client.Subreddit.Get(ctx, "golang")
+		require.NoError(t, err)
+		require.Equal(t, i, subreddit.Subscribers)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	for _, secret := range []string{"secret1", "password1", "secret-token", "secret-refresh-token"} {
+		require.NotContains(t, string(data), secret)
+	}
+	require.Contains(t, string(data), Redacted)
+
+	// the server is gone, but the responses are replayed in the order they were recorded
+	server.Close()
+
+	recorder, err = NewRecorder(path, ModeReplay)
+	require.NoError(t, err)
+
+	client = newClient(t, recorder, server.URL)
+	for i := 1; i <= 2; i++ {
+		subreddit, _, err := client.Subreddit.Get(ctx, "golang")
+		require.NoError(t, err)
+		require.Equal(t, i, subreddit.Subscribers)
+	}
+
+	_, _, err = client.Subreddit.Get(ctx, "golang")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "reddittest: no recorded interaction matches GET "+server.URL+"/r/golang/about")
+}
+
+func TestRecorder_Form(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/api/test", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		fmt.Fprint(w, r.Form.Get("n"))
+	})
+
+	path := filepath.Join(t.TempDir(), "form.json")
+
+	recorder, err := NewRecorder(path, ModeAuto)
+	require.NoError(t, err)
+	require.Equal(t, ModeRecord, recorder.Mode())
+
+	for _, n := range []string{"1", "2"} {
+		resp, err := recorder.Client().PostForm(server.URL+"/api/test?q=query", map[string][]string{"n": {n}})
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	recorder, err = NewRecorder(path, ModeAuto)
+	require.NoError(t, err)
+	require.Equal(t, ModeReplay, recorder.Mode())
+
+	// the interactions are matched by their form, regardless of their order
+	for _, n := range []string{"2", "1"} {
+		resp, err := recorder.Client().PostForm(server.URL+"/api/test?q=query", map[string][]string{"n": {n}})
+		require.NoError(t, err)
+
+		body, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, n, string(body))
+	}
+
+	_, err = recorder.Client().PostForm(server.URL+"/api/test", map[string][]string{"n": {"1"}})
+	require.Error(t, err)
+}
+
+func TestNewRecorder_Error(t *testing.T) {
+	_, err := NewRecorder(filepath.Join(t.TempDir(), "missing.json"), ModeReplay)
+	require.Error(t, err)
+}
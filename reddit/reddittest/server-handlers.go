@@ -0,0 +1,933 @@
+package reddittest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest)
+		return
+	}
+
+	path := strings.TrimSuffix(strings.Trim(r.URL.Path, "/"), ".json")
+	segments := strings.Split(path, "/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if path == "api/v1/access_token" && r.Method == http.MethodPost {
+		s.handleToken(w, r)
+		return
+	}
+
+	me, ok := s.authenticate(r)
+	if !ok {
+		writeError(w, http.StatusUnauthorized)
+		return
+	}
+
+	var handler func(http.ResponseWriter, *http.Request, *user, []string)
+	switch r.Method {
+	case http.MethodGet:
+		switch {
+		case path == "api/v1/me":
+			handler = s.handleMe
+		case match(segments, "user", "*", "about"):
+			handler = s.handleUser
+		case match(segments, "user", "*", "*"):
+			handler = s.handleUserListing
+		case match(segments, "r", "*", "about"):
+			handler = s.handleSubreddit
+		case match(segments, "r", "*", "about", "log"):
+			handler = s.handleModLog
+		case match(segments, "r", "*", "about", "*"):
+			handler = s.handleModListing
+		case match(segments, "r", "*"), match(segments, "r", "*", "*"):
+			handler = s.handleSubredditListing
+		case len(segments) >= 2 && segments[0] == "comments":
+			handler = s.handleComments
+		case path == "api/info":
+			handler = s.handleInfo
+		case match(segments, "by_id", "*"):
+			handler = s.handleInfo
+		case match(segments, "message", "*"):
+			handler = s.handleInbox
+		}
+	case http.MethodPost:
+		if me == nil {
+			// application-only clients can't act as a user
+			writeError(w, http.StatusForbidden)
+			return
+		}
+
+		switch path {
+		case "api/submit":
+			handler = s.handleSubmit
+		case "api/comment":
+			handler = s.handleComment
+		case "api/editusertext":
+			handler = s.handleEdit
+		case "api/del":
+			handler = s.handleDelete
+		case "api/vote":
+			handler = s.handleVote
+		case "api/report":
+			handler = s.handleReport
+		case "api/compose":
+			handler = s.handleCompose
+		case "api/read_message", "api/unread_message":
+			handler = s.handleReadMessage
+		case "api/read_all_messages":
+			handler = s.handleReadAllMessages
+		case "api/remove", "api/approve":
+			handler = s.handleModerate
+		}
+	}
+
+	if handler == nil {
+		writeError(w, http.StatusNotFound)
+		return
+	}
+	handler(w, r, me, segments)
+}
+
+// match reports whether the path segments match the pattern, in which "*" matches any segment.
+func match(segments []string, pattern ...string) bool {
+	if len(segments) != len(pattern) {
+		return false
+	}
+	for i, p := range pattern {
+		if p != "*" && p != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	var username string
+	switch r.PostForm.Get("grant_type") {
+	case "password":
+		u, ok := s.users[strings.ToLower(r.PostForm.Get("username"))]
+		if !ok || u.password != r.PostForm.Get("password") {
+			writeJSON(w, http.StatusOK, map[string]string{"error": "invalid_grant"})
+			return
+		}
+		username = u.name
+	case "client_credentials", "https://oauth.reddit.com/grants/installed_client":
+		// application-only clients are not authenticated as a user
+	default:
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		return
+	}
+
+	token := "token" + s.nextID()
+	s.tokens[token] = username
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": token,
+		"token_type":   "bearer",
+		"expires_in":   3600,
+		"scope":        "*",
+	})
+}
+
+// authenticate returns the user the request's access token was issued to.
+// The user is nil if the token was issued to an application-only client.
+func (s *Server) authenticate(r *http.Request) (*user, bool) {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") {
+		return nil, false
+	}
+
+	username, ok := s.tokens[strings.TrimPrefix(auth, "Bearer ")]
+	if !ok {
+		return nil, false
+	}
+	if username == "" {
+		return nil, true
+	}
+	return s.users[strings.ToLower(username)], true
+}
+
+func (s *Server) handleMe(w http.ResponseWriter, r *http.Request, me *user, segments []string) {
+	if me == nil {
+		writeError(w, http.StatusForbidden)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.renderUser(me)["data"])
+}
+
+func (s *Server) handleUser(w http.ResponseWriter, r *http.Request, me *user, segments []string) {
+	u, ok := s.users[strings.ToLower(segments[1])]
+	if !ok {
+		writeError(w, http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.renderUser(u))
+}
+
+func (s *Server) handleUserListing(w http.ResponseWriter, r *http.Request, me *user, segments []string) {
+	var kinds []string
+	switch segments[2] {
+	case "overview":
+		kinds = []string{kindPost, kindComment}
+	case "submitted":
+		kinds = []string{kindPost}
+	case "comments":
+		kinds = []string{kindComment}
+	default:
+		writeError(w, http.StatusNotFound)
+		return
+	}
+
+	items := s.filterItems(func(it *item) bool {
+		return strings.EqualFold(it.author, segments[1]) && !it.deleted && s.visible(it, me) && hasKind(it, kinds...)
+	})
+	s.writeItems(w, r, me, items)
+}
+
+func (s *Server) handleSubreddit(w http.ResponseWriter, r *http.Request, me *user, segments []string) {
+	sr, ok := s.subreddits[strings.ToLower(segments[1])]
+	if !ok {
+		writeError(w, http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.renderSubreddit(sr, me))
+}
+
+// handleSubredditListing serves the posts or comments of subreddits, e.g. r/golang/new or r/golang+rust/comments.
+func (s *Server) handleSubredditListing(w http.ResponseWriter, r *http.Request, me *user, segments []string) {
+	order := "hot"
+	if len(segments) == 3 {
+		order = segments[2]
+	}
+
+	kinds := []string{kindPost}
+	switch order {
+	case "hot", "new", "top":
+	case "comments":
+		kinds = []string{kindComment}
+	default:
+		writeError(w, http.StatusNotFound)
+		return
+	}
+
+	subreddits, ok := s.lookupSubreddits(segments[1])
+	if !ok {
+		writeError(w, http.StatusNotFound)
+		return
+	}
+
+	items := s.filterItems(func(it *item) bool {
+		return subreddits[it.subreddit] && s.visible(it, me) && hasKind(it, kinds...)
+	})
+	if order == "hot" || order == "top" {
+		sortByScore(items)
+	}
+	s.writeItems(w, r, me, items)
+}
+
+func (s *Server) handleModLog(w http.ResponseWriter, r *http.Request, me *user, segments []string) {
+	subreddits, ok := s.lookupModeratedSubreddits(w, me, segments[1])
+	if !ok {
+		return
+	}
+
+	var mods map[string]bool
+	if v := r.Form.Get("mod"); v != "" {
+		mods = make(map[string]bool)
+		for _, mod := range strings.Split(v, ",") {
+			mods[strings.ToLower(mod)] = true
+		}
+	}
+
+	var entries []entry
+	for i := len(s.modActions) - 1; i >= 0; i-- {
+		a := s.modActions[i]
+		if !subreddits[a.subreddit] {
+			continue
+		}
+		if t := r.Form.Get("type"); t != "" && a.action != t {
+			continue
+		}
+		if mods != nil && !mods[strings.ToLower(a.moderator.name)] {
+			continue
+		}
+		entries = append(entries, entry{a.id, s.renderModAction(a)})
+	}
+	writeListing(w, r, entries)
+}
+
+func (s *Server) handleModListing(w http.ResponseWriter, r *http.Request, me *user, segments []string) {
+	var filter func(*item) bool
+	switch segments[3] {
+	case "modqueue":
+		filter = func(it *item) bool { return !it.approved && !it.removed && len(it.reports) > 0 }
+	case "reports":
+		filter = func(it *item) bool { return !it.removed && len(it.reports) > 0 }
+	case "spam":
+		filter = func(it *item) bool { return it.removed && it.spam }
+	case "unmoderated":
+		filter = func(it *item) bool { return it.kind == kindPost && !it.approved && !it.removed }
+	case "edited":
+		filter = func(it *item) bool { return !it.edited.IsZero() }
+	default:
+		writeError(w, http.StatusNotFound)
+		return
+	}
+
+	subreddits, ok := s.lookupModeratedSubreddits(w, me, segments[1])
+	if !ok {
+		return
+	}
+
+	items := s.filterItems(func(it *item) bool {
+		return subreddits[it.subreddit] && !it.deleted && filter(it)
+	})
+	s.writeItems(w, r, me, items)
+}
+
+// handleComments serves a post and its comments, e.g. comments/abc123.
+func (s *Server) handleComments(w http.ResponseWriter, r *http.Request, me *user, segments []string) {
+	post, ok := s.items[kindPost+"_"+segments[1]]
+	if !ok || !s.visible(post, me) {
+		writeError(w, http.StatusNotFound)
+		return
+	}
+
+	comments := make([]interface{}, 0, len(post.replies))
+	for _, comment := range post.replies {
+		if s.visible(comment, me) {
+			comments = append(comments, s.renderComment(comment, me, true))
+		}
+	}
+
+	writeJSON(w, http.StatusOK, []interface{}{
+		renderListing([]interface{}{s.renderPost(post, me)}, "", ""),
+		renderListing(comments, "", ""),
+	})
+}
+
+// handleInfo serves the things with the full IDs, e.g. api/info?id=t3_abc,t1_def or by_id/t3_abc,t3_def.
+func (s *Server) handleInfo(w http.ResponseWriter, r *http.Request, me *user, segments []string) {
+	ids := r.Form.Get("id")
+	if segments[0] == "by_id" {
+		ids = segments[1]
+	}
+
+	var entries []entry
+	for _, id := range strings.Split(ids, ",") {
+		if it, ok := s.items[id]; ok && s.visible(it, me) {
+			entries = append(entries, entry{id, s.render(it, me)})
+			continue
+		}
+		for _, sr := range s.subreddits {
+			if kindSubreddit+"_"+sr.id == id {
+				entries = append(entries, entry{id, s.renderSubreddit(sr, me)})
+			}
+		}
+	}
+	writeListing(w, r, entries)
+}
+
+func (s *Server) handleInbox(w http.ResponseWriter, r *http.Request, me *user, segments []string) {
+	if me == nil {
+		writeError(w, http.StatusForbidden)
+		return
+	}
+
+	var filter func(*message) bool
+	switch segments[1] {
+	case "inbox":
+		filter = func(m *message) bool { return strings.EqualFold(m.to, me.name) }
+	case "unread":
+		filter = func(m *message) bool { return strings.EqualFold(m.to, me.name) && m.unread }
+	case "mentions":
+		filter = func(m *message) bool { return strings.EqualFold(m.to, me.name) && m.subject == subjectMention }
+	case "messages":
+		filter = func(m *message) bool { return strings.EqualFold(m.to, me.name) && m.kind == kindMessage }
+	case "sent":
+		filter = func(m *message) bool { return strings.EqualFold(m.author, me.name) && m.kind == kindMessage }
+	default:
+		writeError(w, http.StatusNotFound)
+		return
+	}
+
+	var entries []entry
+	for i := len(s.messages) - 1; i >= 0; i-- {
+		if m := s.messages[i]; filter(m) {
+			entries = append(entries, entry{m.fullID(), renderMessage(m)})
+		}
+	}
+	writeListing(w, r, entries)
+}
+
+func (s *Server) handleSubmit(w http.ResponseWriter, r *http.Request, me *user, segments []string) {
+	var url string
+	if r.PostForm.Get("kind") == "link" {
+		url = r.PostForm.Get("url")
+	}
+
+	post, err := s.submit(r.PostForm.Get("sr"), me.name, r.PostForm.Get("title"), r.PostForm.Get("text"), url)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"json": map[string]interface{}{
+			"errors": []interface{}{},
+			"data": map[string]interface{}{
+				"id":   post.id,
+				"name": post.fullID(),
+				"url":  post.url,
+			},
+		},
+	})
+}
+
+func (s *Server) handleComment(w http.ResponseWriter, r *http.Request, me *user, segments []string) {
+	parentID := r.PostForm.Get("parent")
+	if parentID == "" {
+		parentID = r.PostForm.Get("thing_id")
+	}
+
+	comment, err := s.comment(parentID, me.name, r.PostForm.Get("text"))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.render(comment, me)["data"])
+}
+
+func (s *Server) handleEdit(w http.ResponseWriter, r *http.Request, me *user, segments []string) {
+	it, ok := s.items[r.PostForm.Get("thing_id")]
+	if !ok || it.deleted {
+		writeAPIError(w, apiError{"NO_THING_ID", "that thing doesn't exist", "thing_id"})
+		return
+	}
+	if !strings.EqualFold(it.author, me.name) {
+		writeError(w, http.StatusForbidden)
+		return
+	}
+
+	it.body = r.PostForm.Get("text")
+	it.edited = s.tick()
+	writeJSON(w, http.StatusOK, s.render(it, me)["data"])
+}
+
+func (s *Server) handleDelete(w http.ResponseWriter, r *http.Request, me *user, segments []string) {
+	if it, ok := s.items[r.PostForm.Get("id")]; ok && strings.EqualFold(it.author, me.name) {
+		it.deleted = true
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{})
+}
+
+func (s *Server) handleVote(w http.ResponseWriter, r *http.Request, me *user, segments []string) {
+	it, ok := s.items[r.PostForm.Get("id")]
+	if !ok {
+		writeError(w, http.StatusBadRequest)
+		return
+	}
+
+	dir, err := strconv.Atoi(r.PostForm.Get("dir"))
+	if err != nil || dir < -1 || dir > 1 {
+		writeError(w, http.StatusBadRequest)
+		return
+	}
+
+	it.likes[strings.ToLower(me.name)] = dir
+	it.score = 0
+	for _, v := range it.likes {
+		it.score += v
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{})
+}
+
+func (s *Server) handleReport(w http.ResponseWriter, r *http.Request, me *user, segments []string) {
+	it, ok := s.items[r.PostForm.Get("thing_id")]
+	if !ok {
+		writeAPIError(w, apiError{"NO_THING_ID", "that thing doesn't exist", "thing_id"})
+		return
+	}
+
+	it.reports = append(it.reports, r.PostForm.Get("reason"))
+	writeJSON(w, http.StatusOK, map[string]interface{}{"json": map[string]interface{}{"errors": []interface{}{}}})
+}
+
+func (s *Server) handleCompose(w http.ResponseWriter, r *http.Request, me *user, segments []string) {
+	to, ok := s.users[strings.ToLower(r.PostForm.Get("to"))]
+	if !ok {
+		writeAPIError(w, apiError{"USER_DOESNT_EXIST", "that user doesn't exist", "to"})
+		return
+	}
+
+	s.messages = append(s.messages, &message{
+		kind:    kindMessage,
+		id:      s.nextID(),
+		seq:     s.seq,
+		created: s.tick(),
+		subject: r.PostForm.Get("subject"),
+		body:    r.PostForm.Get("text"),
+		author:  me.name,
+		to:      to.name,
+		unread:  true,
+	})
+	writeJSON(w, http.StatusOK, map[string]interface{}{"json": map[string]interface{}{"errors": []interface{}{}}})
+}
+
+func (s *Server) handleReadMessage(w http.ResponseWriter, r *http.Request, me *user, segments []string) {
+	unread := segments[1] == "unread_message"
+
+	ids := make(map[string]bool)
+	for _, id := range strings.Split(r.PostForm.Get("id"), ",") {
+		ids[id] = true
+	}
+
+	for _, m := range s.messages {
+		if ids[m.fullID()] && strings.EqualFold(m.to, me.name) {
+			m.unread = unread
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{})
+}
+
+func (s *Server) handleReadAllMessages(w http.ResponseWriter, r *http.Request, me *user, segments []string) {
+	for _, m := range s.messages {
+		if strings.EqualFold(m.to, me.name) {
+			m.unread = false
+		}
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// handleModerate removes or approves a post or comment.
+func (s *Server) handleModerate(w http.ResponseWriter, r *http.Request, me *user, segments []string) {
+	it, ok := s.items[r.PostForm.Get("id")]
+	if !ok {
+		writeError(w, http.StatusBadRequest)
+		return
+	}
+	if !it.subreddit.moderators[strings.ToLower(me.name)] {
+		writeError(w, http.StatusForbidden)
+		return
+	}
+
+	target := "link"
+	if it.kind == kindComment {
+		target = "comment"
+	}
+
+	var action string
+	if segments[1] == "approve" {
+		action = "approve" + target
+		it.approved, it.removed, it.spam, it.reports = true, false, false, nil
+	} else {
+		action = "remove" + target
+		it.approved, it.removed, it.spam = false, true, r.PostForm.Get("spam") == "true"
+		if it.spam {
+			action = "spam" + target
+		}
+	}
+
+	s.modActions = append(s.modActions, &modAction{
+		id:        newModActionID(),
+		seq:       s.seq,
+		action:    action,
+		created:   s.tick(),
+		moderator: me,
+		subreddit: it.subreddit,
+		target:    it,
+	})
+	writeJSON(w, http.StatusOK, map[string]interface{}{})
+}
+
+// lookupSubreddits returns the subreddits named in a path segment, e.g. golang+rust.
+func (s *Server) lookupSubreddits(names string) (map[*subreddit]bool, bool) {
+	subreddits := make(map[*subreddit]bool)
+	for _, name := range strings.Split(names, "+") {
+		sr, ok := s.subreddits[strings.ToLower(name)]
+		if !ok {
+			return nil, false
+		}
+		subreddits[sr] = true
+	}
+	return subreddits, true
+}
+
+// lookupModeratedSubreddits returns the subreddits named in a path segment, writing an error if the user
+// doesn't moderate all of them. The name "mod" stands for all the subreddits the user moderates.
+func (s *Server) lookupModeratedSubreddits(w http.ResponseWriter, me *user, names string) (map[*subreddit]bool, bool) {
+	if me == nil {
+		writeError(w, http.StatusForbidden)
+		return nil, false
+	}
+
+	if names == "mod" {
+		subreddits := make(map[*subreddit]bool)
+		for _, sr := range s.subreddits {
+			if sr.moderators[strings.ToLower(me.name)] {
+				subreddits[sr] = true
+			}
+		}
+		return subreddits, true
+	}
+
+	subreddits, ok := s.lookupSubreddits(names)
+	if !ok {
+		writeError(w, http.StatusNotFound)
+		return nil, false
+	}
+	for sr := range subreddits {
+		if !sr.moderators[strings.ToLower(me.name)] {
+			writeError(w, http.StatusForbidden)
+			return nil, false
+		}
+	}
+	return subreddits, true
+}
+
+// visible reports whether the user can see the post or comment.
+// Removed ones are only visible to the moderators of their subreddit.
+func (s *Server) visible(it *item, me *user) bool {
+	return !it.removed || (me != nil && it.subreddit.moderators[strings.ToLower(me.name)])
+}
+
+// filterItems returns the posts and comments that satisfy the filter, newest first.
+func (s *Server) filterItems(filter func(*item) bool) []*item {
+	var items []*item
+	for _, it := range s.items {
+		if filter(it) {
+			items = append(items, it)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].seq > items[j].seq
+	})
+	return items
+}
+
+func hasKind(it *item, kinds ...string) bool {
+	for _, kind := range kinds {
+		if it.kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func sortByScore(items []*item) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].score > items[j].score
+	})
+}
+
+func (s *Server) writeItems(w http.ResponseWriter, r *http.Request, me *user, items []*item) {
+	entries := make([]entry, len(items))
+	for i, it := range items {
+		entries[i] = entry{it.fullID(), s.render(it, me)}
+	}
+	writeListing(w, r, entries)
+}
+
+// entry is a thing in a listing, with the full ID used as its anchor.
+type entry struct {
+	name  string
+	thing interface{}
+}
+
+// writeListing writes the page of the listing requested with the limit, after and before parameters.
+func writeListing(w http.ResponseWriter, r *http.Request, entries []entry) {
+	limit, err := strconv.Atoi(r.Form.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 25
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	start, end := 0, len(entries)
+	if after := r.Form.Get("after"); after != "" {
+		start = end
+		for i, e := range entries {
+			if e.name == after {
+				start = i + 1
+				break
+			}
+		}
+	} else if before := r.Form.Get("before"); before != "" {
+		end = 0
+		for i, e := range entries {
+			if e.name == before {
+				end = i
+				break
+			}
+		}
+		if end-limit > start {
+			start = end - limit
+		}
+	}
+	if end-start > limit {
+		end = start + limit
+	}
+
+	page := entries[start:end]
+	things := make([]interface{}, len(page))
+	for i, e := range page {
+		things[i] = e.thing
+	}
+
+	var after, before string
+	if len(page) > 0 && end < len(entries) {
+		after = page[len(page)-1].name
+	}
+	if len(page) > 0 && start > 0 {
+		before = page[0].name
+	}
+
+	writeJSON(w, http.StatusOK, renderListing(things, after, before))
+}
+
+func renderListing(things []interface{}, after, before string) map[string]interface{} {
+	data := map[string]interface{}{
+		"children": things,
+		"dist":     len(things),
+		"modhash":  nil,
+		"after":    nil,
+		"before":   nil,
+	}
+	if after != "" {
+		data["after"] = after
+	}
+	if before != "" {
+		data["before"] = before
+	}
+	return map[string]interface{}{"kind": kindListing, "data": data}
+}
+
+func (s *Server) render(it *item, me *user) map[string]interface{} {
+	if it.kind == kindPost {
+		return s.renderPost(it, me)
+	}
+	return s.renderComment(it, me, false)
+}
+
+func (s *Server) renderPost(post *item, me *user) map[string]interface{} {
+	data := s.renderItem(post, me)
+	data["title"] = post.title
+	data["selftext"] = data["body"]
+	data["url"] = post.url
+	data["is_self"] = post.url == s.URL+permalink(post)
+	data["num_comments"] = post.comments
+	data["upvote_ratio"] = 1
+	delete(data, "body")
+	return map[string]interface{}{"kind": kindPost, "data": data}
+}
+
+func (s *Server) renderComment(comment *item, me *user, withReplies bool) map[string]interface{} {
+	data := s.renderItem(comment, me)
+	data["parent_id"] = comment.parentID
+	data["link_id"] = comment.post.fullID()
+	data["link_title"] = comment.post.title
+	data["link_permalink"] = s.URL + permalink(comment.post)
+	data["link_author"] = comment.post.author
+	data["num_comments"] = comment.post.comments
+	data["is_submitter"] = strings.EqualFold(comment.author, comment.post.author)
+	data["replies"] = ""
+
+	if withReplies {
+		var replies []interface{}
+		for _, reply := range comment.replies {
+			if s.visible(reply, me) {
+				replies = append(replies, s.renderComment(reply, me, true))
+			}
+		}
+		if len(replies) > 0 {
+			data["replies"] = renderListing(replies, "", "")
+		}
+	}
+
+	return map[string]interface{}{"kind": kindComment, "data": data}
+}
+
+// renderItem returns the fields that posts and comments have in common.
+func (s *Server) renderItem(it *item, me *user) map[string]interface{} {
+	author, body := it.author, it.body
+	var authorID string
+	if it.deleted {
+		author, body = "[deleted]", "[deleted]"
+	} else if u, ok := s.users[strings.ToLower(it.author)]; ok {
+		authorID = kindUser + "_" + u.id
+	}
+
+	var likes interface{}
+	if me != nil {
+		switch it.likes[strings.ToLower(me.name)] {
+		case 1:
+			likes = true
+		case -1:
+			likes = false
+		}
+	}
+
+	var edited interface{} = false
+	if !it.edited.IsZero() {
+		edited = float64(it.edited.Unix())
+	}
+
+	reports := make([][]interface{}, len(it.reports))
+	for i, reason := range it.reports {
+		reports[i] = []interface{}{reason, 1}
+	}
+
+	return map[string]interface{}{
+		"id":                      it.id,
+		"name":                    it.fullID(),
+		"created_utc":             float64(it.created.Unix()),
+		"edited":                  edited,
+		"permalink":               permalink(it),
+		"body":                    body,
+		"author":                  author,
+		"author_fullname":         authorID,
+		"subreddit":               it.subreddit.name,
+		"subreddit_name_prefixed": "r/" + it.subreddit.name,
+		"subreddit_id":            kindSubreddit + "_" + it.subreddit.id,
+		"likes":                   likes,
+		"score":                   it.score,
+		"approved":                it.approved,
+		"removed":                 it.removed,
+		"spam":                    it.spam,
+		"num_reports":             len(it.reports),
+		"user_reports":            reports,
+	}
+}
+
+func (s *Server) renderSubreddit(sr *subreddit, me *user) map[string]interface{} {
+	return map[string]interface{}{
+		"kind": kindSubreddit,
+		"data": map[string]interface{}{
+			"id":                    sr.id,
+			"name":                  kindSubreddit + "_" + sr.id,
+			"created_utc":           float64(sr.created.Unix()),
+			"url":                   "/r/" + sr.name + "/",
+			"display_name":          sr.name,
+			"display_name_prefixed": "r/" + sr.name,
+			"title":                 sr.name,
+			"subreddit_type":        "public",
+			"subscribers":           0,
+			"user_is_moderator":     me != nil && sr.moderators[strings.ToLower(me.name)],
+		},
+	}
+}
+
+func (s *Server) renderUser(u *user) map[string]interface{} {
+	var postKarma, commentKarma int
+	for _, it := range s.items {
+		if !strings.EqualFold(it.author, u.name) || it.deleted {
+			continue
+		}
+		if it.kind == kindPost {
+			postKarma += it.score
+		} else {
+			commentKarma += it.score
+		}
+	}
+
+	return map[string]interface{}{
+		"kind": kindUser,
+		"data": map[string]interface{}{
+			"id":            u.id,
+			"name":          u.name,
+			"created_utc":   float64(u.created.Unix()),
+			"link_karma":    postKarma,
+			"comment_karma": commentKarma,
+		},
+	}
+}
+
+func renderMessage(m *message) map[string]interface{} {
+	data := map[string]interface{}{
+		"id":          m.id,
+		"name":        m.fullID(),
+		"created_utc": float64(m.created.Unix()),
+		"subject":     m.subject,
+		"body":        m.body,
+		"parent_id":   m.parentID,
+		"author":      m.author,
+		"dest":        m.to,
+		"was_comment": m.comment != nil,
+		"new":         m.unread,
+	}
+	if m.comment != nil {
+		data["context"] = permalink(m.comment) + "?context=3"
+		data["link_title"] = m.comment.post.title
+		data["subreddit"] = m.comment.subreddit.name
+	}
+	return map[string]interface{}{"kind": m.kind, "data": data}
+}
+
+func (s *Server) renderModAction(a *modAction) map[string]interface{} {
+	data := map[string]interface{}{
+		"id":               a.id,
+		"action":           a.action,
+		"created_utc":      float64(a.created.Unix()),
+		"mod":              a.moderator.name,
+		"mod_id36":         a.moderator.id,
+		"subreddit":        a.subreddit.name,
+		"sr_id36":          a.subreddit.id,
+		"target_fullname":  a.target.fullID(),
+		"target_author":    a.target.author,
+		"target_permalink": permalink(a.target),
+	}
+	if a.target.kind == kindPost {
+		data["target_title"] = a.target.title
+	} else {
+		data["target_body"] = a.target.body
+	}
+	return map[string]interface{}{"kind": kindModAction, "data": data}
+}
+
+// permalink returns the path of the post or comment.
+func permalink(it *item) string {
+	if it.kind == kindComment {
+		return fmt.Sprintf("/r/%s/comments/%s/_/%s/", it.subreddit.name, it.post.id, it.id)
+	}
+	return fmt.Sprintf("/r/%s/comments/%s/_/", it.subreddit.name, it.id)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error response in the format used by Reddit, e.g. {"message": "Not Found", "error": 404}.
+func writeError(w http.ResponseWriter, status int) {
+	writeJSON(w, status, map[string]interface{}{
+		"message": http.StatusText(status),
+		"error":   status,
+	})
+}
+
+// writeAPIError writes the error in the "json.errors" field of the response, like Reddit does for
+// requests made with api_type=json. Other errors are written as a 400 Bad Request.
+func writeAPIError(w http.ResponseWriter, err error) {
+	apiErr, ok := err.(apiError)
+	if !ok {
+		writeError(w, http.StatusBadRequest)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"json": map[string]interface{}{
+			"errors": []apiError{apiErr},
+		},
+	})
+}
@@ -0,0 +1,431 @@
+package reddittest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vartanbeno/go-reddit/v2/reddit"
+)
+
+const (
+	kindComment   = "t1"
+	kindUser      = "t2"
+	kindPost      = "t3"
+	kindMessage   = "t4"
+	kindSubreddit = "t5"
+	kindModAction = "modaction"
+	kindListing   = "Listing"
+)
+
+// subjectMention is the subject of the message notifying a user that they were mentioned in a comment.
+const subjectMention = "username mention"
+
+// mentionPattern matches the usernames mentioned in a comment, e.g. u/name or /u/name.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w/])/?u/([\w-]+)`)
+
+// Server is an in-memory fake of the Reddit API, for testing code that uses the reddit package.
+// It keeps a model of users, subreddits, posts, comments, messages and moderation actions,
+// and implements the endpoints most commonly used by bots with the same semantics as Reddit:
+// e.g. a submitted post shows up in its subreddit's listings, replying to it sends a message
+// to its author's inbox, and removing it as a moderator adds an entry to the moderation log.
+//
+// It also serves the token endpoint, so clients authenticate with it like with Reddit:
+//
+//	server := reddittest.NewServer()
+//	defer server.Close()
+//	server.AddUser("bot", "password")
+//
+//	client, _ := reddit.NewClient(
+//		reddit.Credentials{ID: "id", Secret: "secret", Username: "bot", Password: "password"},
+//		reddit.WithBaseURL(server.URL),
+//		reddit.WithTokenURL(server.TokenURL()),
+//	)
+//
+// Requests to endpoints it doesn't implement fail with a 404 Not Found.
+// It is safe for concurrent use.
+type Server struct {
+	// Base URL of the server, e.g. http://127.0.0.1:12345
+	URL string
+
+	server *httptest.Server
+
+	mu         sync.Mutex
+	seq        int64
+	now        time.Time
+	users      map[string]*user
+	tokens     map[string]string
+	subreddits map[string]*subreddit
+	items      map[string]*item
+	messages   []*message
+	modActions []*modAction
+}
+
+type user struct {
+	id       string
+	name     string
+	password string
+	created  time.Time
+}
+
+type subreddit struct {
+	id         string
+	name       string
+	created    time.Time
+	moderators map[string]bool
+}
+
+// item is a post or a comment.
+type item struct {
+	kind      string
+	id        string
+	seq       int64
+	subreddit *subreddit
+	author    string
+	created   time.Time
+	edited    time.Time
+	body      string
+	score     int
+	likes     map[string]int
+
+	// only set for posts
+	title    string
+	url      string
+	comments int
+
+	// only set for comments
+	post     *item
+	parentID string
+	replies  []*item
+
+	removed  bool
+	spam     bool
+	approved bool
+	deleted  bool
+	reports  []string
+}
+
+func (i *item) fullID() string {
+	return i.kind + "_" + i.id
+}
+
+// message is a private message, or a notification of a reply or mention in someone's inbox.
+type message struct {
+	kind     string
+	id       string
+	seq      int64
+	created  time.Time
+	subject  string
+	body     string
+	author   string
+	to       string
+	parentID string
+	unread   bool
+	// set if the message is about a comment
+	comment *item
+}
+
+func (m *message) fullID() string {
+	return m.kind + "_" + m.id
+}
+
+type modAction struct {
+	id        string
+	seq       int64
+	action    string
+	created   time.Time
+	moderator *user
+	subreddit *subreddit
+	target    *item
+}
+
+// NewServer starts and returns a new Server. Call Close when done with it.
+func NewServer() *Server {
+	s := &Server{
+		// ids start at a few characters, like Reddit's
+		seq:        36 * 36 * 36,
+		now:        time.Now().UTC().Truncate(time.Second),
+		users:      make(map[string]*user),
+		tokens:     make(map[string]string),
+		subreddits: make(map[string]*subreddit),
+		items:      make(map[string]*item),
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// TokenURL returns the URL of the server's token endpoint, to be used with reddit.WithTokenURL.
+func (s *Server) TokenURL() string {
+	return s.URL + "/api/v1/access_token"
+}
+
+// Client returns a new client authenticated as the user, which must have been added with AddUser.
+// The options are applied after the ones pointing the client to the server.
+func (s *Server) Client(username string, opts ...reddit.Opt) (*reddit.Client, error) {
+	s.mu.Lock()
+	u, ok := s.users[strings.ToLower(username)]
+	s.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("reddittest: user %q does not exist", username)
+	}
+
+	opts = append([]reddit.Opt{reddit.WithBaseURL(s.URL), reddit.WithTokenURL(s.TokenURL())}, opts...)
+	return reddit.NewClient(reddit.Credentials{ID: "id", Secret: "secret", Username: u.name, Password: u.password}, opts...)
+}
+
+// AddUser adds a user who can authenticate with the password.
+func (s *Server) AddUser(name, password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.users[strings.ToLower(name)] = &user{
+		id:       s.nextID(),
+		name:     name,
+		password: password,
+		created:  s.tick(),
+	}
+}
+
+// AddSubreddit adds a subreddit moderated by the users, which must have been added with AddUser.
+func (s *Server) AddSubreddit(name string, moderators ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sr := &subreddit{
+		id:         s.nextID(),
+		name:       name,
+		created:    s.tick(),
+		moderators: make(map[string]bool),
+	}
+	for _, moderator := range moderators {
+		sr.moderators[strings.ToLower(moderator)] = true
+	}
+	s.subreddits[strings.ToLower(name)] = sr
+}
+
+// AddPost adds a text post to the subreddit and returns its full ID, e.g. t3_abc123.
+func (s *Server) AddPost(subreddit, author, title, body string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	post, err := s.submit(subreddit, author, title, body, "")
+	if err != nil {
+		return "", err
+	}
+	return post.fullID(), nil
+}
+
+// AddComment adds a comment replying to the post or comment with the full ID, and returns its full ID.
+func (s *Server) AddComment(parentID, author, body string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	comment, err := s.comment(parentID, author, body)
+	if err != nil {
+		return "", err
+	}
+	return comment.fullID(), nil
+}
+
+// Report reports the post or comment with the full ID, which puts it in its subreddit's moderation queue.
+func (s *Server) Report(id, reason string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	it, ok := s.items[id]
+	if !ok {
+		return fmt.Errorf("reddittest: %s does not exist", id)
+	}
+	it.reports = append(it.reports, reason)
+	return nil
+}
+
+// Removed reports whether the post or comment with the full ID was removed by a moderator.
+func (s *Server) Removed(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	it, ok := s.items[id]
+	return ok && it.removed
+}
+
+// Approved reports whether the post or comment with the full ID was approved by a moderator.
+func (s *Server) Approved(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	it, ok := s.items[id]
+	return ok && it.approved
+}
+
+// Deleted reports whether the post or comment with the full ID was deleted by its author.
+func (s *Server) Deleted(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	it, ok := s.items[id]
+	return ok && it.deleted
+}
+
+// nextID returns a new ID36.
+func (s *Server) nextID() string {
+	s.seq++
+	return strconv.FormatInt(s.seq, 36)
+}
+
+// tick returns the time at which something new happens.
+// Every call is a second after the previous one, so that things are ordered by their creation time.
+func (s *Server) tick() time.Time {
+	s.now = s.now.Add(time.Second)
+	return s.now
+}
+
+func (s *Server) submit(subredditName, author, title, body, url string) (*item, error) {
+	sr, ok := s.subreddits[strings.ToLower(subredditName)]
+	if !ok {
+		return nil, apiError{"SUBREDDIT_NOEXIST", "that subreddit doesn't exist", "sr"}
+	}
+	if title == "" {
+		return nil, apiError{"NO_TEXT", "we need something here", "title"}
+	}
+
+	post := &item{
+		kind:      kindPost,
+		id:        s.nextID(),
+		seq:       s.seq,
+		subreddit: sr,
+		author:    author,
+		created:   s.tick(),
+		title:     title,
+		body:      body,
+		url:       url,
+		score:     1,
+		likes:     map[string]int{strings.ToLower(author): 1},
+	}
+	if post.url == "" {
+		post.url = s.URL + permalink(post)
+	}
+
+	s.items[post.fullID()] = post
+	return post, nil
+}
+
+func (s *Server) comment(parentID, author, body string) (*item, error) {
+	parent, ok := s.items[parentID]
+	if !ok || parent.deleted {
+		return nil, apiError{"NO_THING_ID", "that thing doesn't exist", "parent"}
+	}
+	if body == "" {
+		return nil, apiError{"NO_TEXT", "we need something here", "text"}
+	}
+
+	post := parent
+	if parent.kind == kindComment {
+		post = parent.post
+	}
+
+	comment := &item{
+		kind:      kindComment,
+		id:        s.nextID(),
+		seq:       s.seq,
+		subreddit: post.subreddit,
+		author:    author,
+		created:   s.tick(),
+		body:      body,
+		score:     1,
+		likes:     map[string]int{strings.ToLower(author): 1},
+		post:      post,
+		parentID:  parentID,
+	}
+
+	s.items[comment.fullID()] = comment
+	parent.replies = append(parent.replies, comment)
+	post.comments++
+
+	notified := map[string]bool{strings.ToLower(author): true}
+
+	// the author of the parent gets notified of the reply
+	if !notified[strings.ToLower(parent.author)] {
+		notified[strings.ToLower(parent.author)] = true
+
+		subject := "comment reply"
+		if parent.kind == kindPost {
+			subject = "post reply"
+		}
+		s.messages = append(s.messages, &message{
+			kind:     kindComment,
+			id:       comment.id,
+			seq:      comment.seq,
+			created:  comment.created,
+			subject:  subject,
+			body:     body,
+			author:   author,
+			to:       parent.author,
+			parentID: parentID,
+			unread:   true,
+			comment:  comment,
+		})
+	}
+
+	// and so do the users mentioned in it, unless they already were
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		mentioned, ok := s.users[strings.ToLower(match[1])]
+		if !ok || notified[strings.ToLower(mentioned.name)] {
+			continue
+		}
+		notified[strings.ToLower(mentioned.name)] = true
+
+		s.messages = append(s.messages, &message{
+			kind:     kindComment,
+			id:       comment.id,
+			seq:      comment.seq,
+			created:  comment.created,
+			subject:  subjectMention,
+			body:     body,
+			author:   author,
+			to:       mentioned.name,
+			parentID: parentID,
+			unread:   true,
+			comment:  comment,
+		})
+	}
+
+	return comment, nil
+}
+
+// apiError is an error reported in the "json.errors" field of a response, like Reddit's APIError.
+type apiError struct {
+	label  string
+	reason string
+	field  string
+}
+
+func (e apiError) Error() string {
+	return fmt.Sprintf("%s: %s", e.label, e.reason)
+}
+
+func (e apiError) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]string{e.label, e.reason, e.field})
+}
+
+// newModActionID returns the ID of a moderation action, e.g. ModAction_1b3c5e7a-...
+func newModActionID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	h := hex.EncodeToString(b)
+	return fmt.Sprintf("ModAction_%s-%s-%s-%s-%s", h[:8], h[8:12], h[12:16], h[16:20], h[20:])
+}
@@ -0,0 +1,270 @@
+package reddittest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vartanbeno/go-reddit/v2/reddit"
+)
+
+func newTestServer(t *testing.T) *Server {
+	server := NewServer()
+	t.Cleanup(server.Close)
+
+	server.AddUser("author", "password1")
+	server.AddUser("replier", "password2")
+	server.AddUser("mod", "password3")
+	server.AddSubreddit("golang", "mod")
+	return server
+}
+
+func newServerClient(t *testing.T, server *Server, username string) *reddit.Client {
+	client, err := server.Client(username)
+	require.NoError(t, err)
+	return client
+}
+
+func TestServer_Authentication(t *testing.T) {
+	server := newTestServer(t)
+
+	client := newServerClient(t, server, "author")
+	user, _, err := client.User.Get(ctx, "author")
+	require.NoError(t, err)
+	require.Equal(t, "author", user.Name)
+
+	_, err = server.Client("nobody")
+	require.EqualError(t, err, `reddittest: user "nobody" does not exist`)
+
+	client, err = reddit.NewClient(
+		reddit.Credentials{ID: "id", Secret: "secret", Username: "author", Password: "wrong"},
+		reddit.WithBaseURL(server.URL),
+		reddit.WithTokenURL(server.TokenURL()),
+	)
+	require.NoError(t, err)
+
+	_, _, err = client.User.Get(ctx, "author")
+	require.Error(t, err)
+}
+
+func TestServer_PostsAndComments(t *testing.T) {
+	server := newTestServer(t)
+	author := newServerClient(t, server, "author")
+	replier := newServerClient(t, server, "replier")
+
+	submitted, _, err := author.Post.SubmitText(ctx, reddit.SubmitTextRequest{
+		Subreddit: "golang",
+		Title:     "Test Title",
+		Text:      "Test Text",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "t3_"+submitted.ID, submitted.FullID)
+
+	comment, _, err := replier.Comment.Submit(ctx, submitted.FullID, "Test Comment")
+	require.NoError(t, err)
+	require.Equal(t, submitted.FullID, comment.PostID)
+	require.Equal(t, "replier", comment.Author)
+
+	reply, err := server.AddComment(comment.FullID, "author", "Test Reply")
+	require.NoError(t, err)
+
+	postAndComments, _, err := author.Post.Get(ctx, submitted.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Test Title", postAndComments.Post.Title)
+	require.Equal(t, "Test Text", postAndComments.Post.Body)
+	require.Equal(t, 2, postAndComments.Post.NumberOfComments)
+	require.Len(t, postAndComments.Comments, 1)
+	require.Len(t, postAndComments.Comments[0].Replies.Comments, 1)
+	require.Equal(t, reply, postAndComments.Comments[0].Replies.Comments[0].FullID)
+
+	edited, _, err := replier.Comment.Edit(ctx, comment.FullID, "Edited Comment")
+	require.NoError(t, err)
+	require.Equal(t, "Edited Comment", edited.Body)
+	require.NotNil(t, edited.Edited)
+
+	posts, resp, err := author.Subreddit.NewPosts(ctx, "golang", nil)
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+	require.Equal(t, submitted.FullID, posts[0].FullID)
+	require.Empty(t, resp.After)
+
+	posts, comments, _, _, err := author.Listings.Get(ctx, submitted.FullID, comment.FullID)
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+	require.Len(t, comments, 1)
+	require.Equal(t, "Edited Comment", comments[0].Body)
+
+	_, err = author.Comment.Delete(ctx, reply)
+	require.NoError(t, err)
+	require.True(t, server.Deleted(reply))
+
+	_, _, err = author.Post.SubmitText(ctx, reddit.SubmitTextRequest{Subreddit: "nope", Title: "Test Title"})
+	require.True(t, errors.Is(err, reddit.ErrNotFound))
+}
+
+func TestServer_Pagination(t *testing.T) {
+	server := newTestServer(t)
+	client := newServerClient(t, server, "author")
+
+	for i := 0; i < 5; i++ {
+		_, err := server.AddPost("golang", "author", "Test Title", "")
+		require.NoError(t, err)
+	}
+
+	it := reddit.NewPostIterator(func(ctx context.Context, opts *reddit.ListOptions) ([]*reddit.Post, *reddit.Response, error) {
+		return client.Subreddit.NewPosts(ctx, "golang", opts)
+	}, &reddit.IteratorOptions{PageSize: 2})
+
+	posts, err := it.All(ctx)
+	require.NoError(t, err)
+	require.Len(t, posts, 5)
+	for i := 1; i < len(posts); i++ {
+		require.True(t, posts[i-1].Created.After(posts[i].Created.Time))
+	}
+}
+
+func TestServer_Inbox(t *testing.T) {
+	server := newTestServer(t)
+	author := newServerClient(t, server, "author")
+	replier := newServerClient(t, server, "replier")
+
+	post, err := server.AddPost("golang", "author", "Test Title", "")
+	require.NoError(t, err)
+	_, _, err = replier.Comment.Submit(ctx, post, "Test Comment")
+	require.NoError(t, err)
+
+	_, err = replier.Message.Send(ctx, &reddit.SendMessageRequest{To: "author", Subject: "Test Subject", Text: "Test Message"})
+	require.NoError(t, err)
+
+	comments, messages, _, err := author.Message.InboxUnread(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	require.Equal(t, "post reply", comments[0].Subject)
+	require.Equal(t, "Test Comment", comments[0].Text)
+	require.Len(t, messages, 1)
+	require.Equal(t, "Test Message", messages[0].Text)
+	require.Equal(t, "replier", messages[0].Author)
+
+	_, err = author.Message.Read(ctx, comments[0].FullID, messages[0].FullID)
+	require.NoError(t, err)
+
+	comments, messages, _, err = author.Message.InboxUnread(ctx, nil)
+	require.NoError(t, err)
+	require.Empty(t, comments)
+	require.Empty(t, messages)
+
+	comments, messages, _, err = author.Message.Inbox(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	require.Len(t, messages, 1)
+
+	sent, _, err := replier.Message.Sent(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, sent, 1)
+}
+
+func TestServer_Mentions(t *testing.T) {
+	server := newTestServer(t)
+	server.AddUser("mentioned", "password4")
+	author := newServerClient(t, server, "author")
+	mentioned := newServerClient(t, server, "mentioned")
+
+	post, err := server.AddPost("golang", "author", "Test Title", "")
+	require.NoError(t, err)
+	_, _, err = author.Comment.Submit(ctx, post, "thanks u/mentioned and /u/author")
+	require.NoError(t, err)
+
+	messages, errs, stop := mentioned.Stream.Mentions(reddit.StreamMarkRead, reddit.StreamMaxRequests(1))
+	defer stop()
+
+	message := <-messages
+	require.Equal(t, "username mention", message.Subject)
+	require.Equal(t, "author", message.Author)
+	require.True(t, message.Unread)
+
+	_, ok := <-messages
+	require.False(t, ok)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	comments, _, _, err := mentioned.Message.InboxUnread(ctx, nil)
+	require.NoError(t, err)
+	require.Empty(t, comments)
+
+	// the author isn't notified of their own mention
+	comments, _, _, err = author.Message.Inbox(ctx, nil)
+	require.NoError(t, err)
+	require.Empty(t, comments)
+}
+
+func TestServer_Moderation(t *testing.T) {
+	server := newTestServer(t)
+	mod := newServerClient(t, server, "mod")
+	author := newServerClient(t, server, "author")
+
+	post1, err := server.AddPost("golang", "author", "Test Title 1", "")
+	require.NoError(t, err)
+	post2, err := server.AddPost("golang", "author", "Test Title 2", "")
+	require.NoError(t, err)
+	require.NoError(t, server.Report(post1, "spam"))
+
+	posts, _, _, err := mod.Moderation.Queue(ctx, "golang", nil)
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+	require.Equal(t, post1, posts[0].FullID)
+
+	_, err = author.Moderation.Remove(ctx, post1)
+	require.True(t, errors.Is(err, reddit.ErrForbidden))
+	require.False(t, server.Removed(post1))
+
+	_, _, _, err = author.Moderation.Queue(ctx, "golang", nil)
+	require.True(t, errors.Is(err, reddit.ErrForbidden))
+
+	_, err = mod.Moderation.RemoveSpam(ctx, post1)
+	require.NoError(t, err)
+	require.True(t, server.Removed(post1))
+
+	_, err = mod.Moderation.Approve(ctx, post2)
+	require.NoError(t, err)
+	require.True(t, server.Approved(post2))
+
+	posts, _, _, err = mod.Moderation.Queue(ctx, "golang", nil)
+	require.NoError(t, err)
+	require.Empty(t, posts)
+
+	posts, _, _, err = mod.Moderation.Spam(ctx, "golang", nil)
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+
+	// removed posts are hidden from everyone but moderators
+	posts, _, err = author.Subreddit.NewPosts(ctx, "golang", nil)
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+	require.Equal(t, post2, posts[0].FullID)
+
+	actions, _, err := mod.Moderation.Actions(ctx, "golang", nil)
+	require.NoError(t, err)
+	require.Len(t, actions, 2)
+	require.Equal(t, "approvelink", actions[0].Action)
+	require.Equal(t, post2, actions[0].TargetID)
+	require.Equal(t, "spamlink", actions[1].Action)
+	require.Equal(t, "mod", actions[1].Moderator)
+	require.Equal(t, "Test Title 1", actions[1].TargetTitle)
+
+	actions, _, err = mod.Moderation.Actions(ctx, "golang", &reddit.ListModActionOptions{Type: "spamlink"})
+	require.NoError(t, err)
+	require.Len(t, actions, 1)
+}
+
+func TestServer_NotFound(t *testing.T) {
+	server := newTestServer(t)
+	client := newServerClient(t, server, "author")
+
+	_, _, err := client.Subreddit.Get(ctx, "nope")
+	require.True(t, errors.Is(err, reddit.ErrNotFound))
+
+	_, _, err = client.Wiki.Page(ctx, "golang", "index")
+	require.True(t, errors.Is(err, reddit.ErrNotFound))
+}
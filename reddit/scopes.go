@@ -0,0 +1,305 @@
+package reddit
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+// ScopesService handles communication with the OAuth2 scope
+// related methods of the Reddit API.
+//
+// Reddit API docs: https://www.reddit.com/dev/api/#GET_api_v1_scopes
+type ScopesService struct {
+	client *Client
+}
+
+// Scope is an OAuth2 scope, which grants an app access to a set of endpoints.
+type Scope struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// List returns all the OAuth2 scopes an app can request, ordered by their ID.
+func (s *ScopesService) List(ctx context.Context) ([]*Scope, *Response, error) {
+	path := "api/v1/scopes"
+	req, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := make(map[string]*Scope)
+	resp, err := s.client.Do(ctx, req, &root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	scopes := make([]*Scope, 0, len(root))
+	for _, scope := range root {
+		scopes = append(scopes, scope)
+	}
+	sort.Slice(scopes, func(i, j int) bool {
+		return scopes[i].ID < scopes[j].ID
+	})
+
+	return scopes, resp, nil
+}
+
+// GrantedScopes returns the OAuth2 scopes granted to the client's access token, as reported
+// by Reddit when the token was obtained. The scope "*" means all scopes were granted.
+// It returns nil if they're unknown, e.g. before the first request is made, or if the client
+// was initialized with a token that doesn't include its scopes (such as one from a TokenStore).
+func (c *Client) GrantedScopes() []string {
+	c.scopesMu.Lock()
+	defer c.scopesMu.Unlock()
+
+	if c.scopes == nil {
+		return nil
+	}
+	return append([]string(nil), c.scopes...)
+}
+
+func (c *Client) setGrantedScopes(scopes []string) {
+	c.scopesMu.Lock()
+	defer c.scopesMu.Unlock()
+	c.scopes = scopes
+}
+
+// parseScopes parses the "scope" field of a token response.
+// Reddit separates the scopes by spaces or commas.
+func parseScopes(v string) []string {
+	return strings.FieldsFunc(v, func(r rune) bool {
+		return r == ' ' || r == ','
+	})
+}
+
+// scopeRecordingTokenSource records the scopes granted to each new token in the client.
+type scopeRecordingTokenSource struct {
+	client *Client
+	base   oauth2.TokenSource
+
+	mu   sync.Mutex
+	last *oauth2.Token
+}
+
+func (s *scopeRecordingTokenSource) Token() (*oauth2.Token, error) {
+	token, err := s.base.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if token != s.last {
+		s.last = token
+		if scope, ok := token.Extra("scope").(string); ok {
+			s.client.setGrantedScopes(parseScopes(scope))
+		}
+	}
+
+	return token, nil
+}
+
+// endpointScopes maps endpoints to the OAuth2 scope they require.
+// An empty method matches any method. In patterns, "*" matches any single path segment,
+// and a pattern matches any path that starts with its segments.
+// The first matching entry wins, so more specific patterns come first.
+var endpointScopes = []struct {
+	method  string
+	pattern string
+	scope   string
+}{
+	{http.MethodPatch, "api/v1/me/prefs", "account"},
+	{"", "api/v1/me/karma", "mysubreddits"},
+	{"", "api/v1/me/friends", "subscribe"},
+	{"", "api/v1/me", "identity"},
+	{"", "subreddits/mine", "mysubreddits"},
+
+	{"", "api/v1/gold", "creddits"},
+
+	{"", "api/submit", "submit"},
+	{"", "api/comment", "submit"},
+	{"", "api/editusertext", "edit"},
+	{"", "api/del", "edit"},
+	{"", "api/sendreplies", "edit"},
+	{"", "api/vote", "vote"},
+	{"", "api/save", "save"},
+	{"", "api/unsave", "save"},
+	{"", "api/store_visits", "save"},
+	{"", "api/report", "report"},
+	{"", "api/hide", "report"},
+	{"", "api/unhide", "report"},
+
+	{"", "api/block", "privatemessages"},
+	{"", "api/collapse_message", "privatemessages"},
+	{"", "api/uncollapse_message", "privatemessages"},
+	{"", "api/compose", "privatemessages"},
+	{"", "api/del_msg", "privatemessages"},
+	{"", "api/read_all_messages", "privatemessages"},
+	{"", "api/read_message", "privatemessages"},
+	{"", "api/unread_message", "privatemessages"},
+	{"", "message", "privatemessages"},
+
+	{"", "api/subscribe", "subscribe"},
+	{"", "api/favorite", "subscribe"},
+	{"", "api/unfriend", "subscribe"},
+	{"", "api/v1/collections/follow_collection", "subscribe"},
+	{http.MethodGet, "api/multi", "read"},
+	{"", "api/multi", "subscribe"},
+
+	{"", "api/approve", "modposts"},
+	{"", "api/remove", "modposts"},
+	{"", "api/distinguish", "modposts"},
+	{"", "api/ignore_reports", "modposts"},
+	{"", "api/unignore_reports", "modposts"},
+	{"", "api/lock", "modposts"},
+	{"", "api/unlock", "modposts"},
+	{"", "api/marknsfw", "modposts"},
+	{"", "api/unmarknsfw", "modposts"},
+	{"", "api/spoiler", "modposts"},
+	{"", "api/unspoiler", "modposts"},
+	{"", "api/set_contest_mode", "modposts"},
+	{"", "api/set_subreddit_sticky", "modposts"},
+	{"", "api/set_suggested_sort", "modposts"},
+	{http.MethodPost, "api/v1/collections", "modposts"},
+	{"", "r/*/about/reports", "modposts"},
+	{"", "r/*/about/spam", "modposts"},
+	{"", "r/*/about/modqueue", "modposts"},
+	{"", "r/*/about/unmoderated", "modposts"},
+	{"", "r/*/about/edited", "modposts"},
+	{"", "r/*/about/log", "modlog"},
+
+	{"", "api/leavecontributor", "modself"},
+	{"", "api/leavemoderator", "modself"},
+	{"", "r/*/api/accept_moderator_invite", "modself"},
+	{"", "r/*/api/setpermissions", "modothers"},
+
+	{"", "api/site_admin", "modconfig"},
+	{"", "r/*/about/edit", "modconfig"},
+	{"", "r/*/about/traffic", "modconfig"},
+	{"", "r/*/api/subreddit_stylesheet", "modconfig"},
+	{"", "r/*/api/upload_sr_img", "modconfig"},
+	{"", "r/*/api/delete_sr_banner", "modconfig"},
+	{"", "r/*/api/delete_sr_header", "modconfig"},
+	{"", "r/*/api/delete_sr_icon", "modconfig"},
+	{"", "r/*/api/delete_sr_img", "modconfig"},
+	{"", "r/*/api/add_subreddit_rule", "modconfig"},
+
+	{"", "r/*/api/selectflair", "flair"},
+	{"", "r/*/api/flairselector", "flair"},
+	{"", "r/*/api/link_flair_v2", "flair"},
+	{"", "r/*/api/user_flair_v2", "flair"},
+	{"", "api/flairselector", "flair"},
+	{"", "r/*/api/clearflairtemplates", "modflair"},
+	{"", "r/*/api/deleteflair", "modflair"},
+	{"", "r/*/api/deleteflairtemplate", "modflair"},
+	{"", "r/*/api/flairconfig", "modflair"},
+	{"", "r/*/api/flaircsv", "modflair"},
+	{"", "r/*/api/flairlist", "modflair"},
+	{"", "r/*/api/flairtemplate_v2", "modflair"},
+	{"", "r/*/api/setflairenabled", "modflair"},
+	{"", "api/v1/*/flair_template_order", "modflair"},
+
+	{"", "r/*/api/wiki/edit", "wikiedit"},
+	{"", "r/*/api/wiki", "modwiki"},
+	{"", "r/*/wiki/settings", "modwiki"},
+	{"", "r/*/wiki", "wikiread"},
+
+	{http.MethodGet, "r/*/api/widgets", "read"},
+	{"", "r/*/api/widget", "structuredstyles"},
+	{"", "r/*/api/widget_order", "structuredstyles"},
+	{http.MethodGet, "api/v1/*/emojis", "read"},
+	{"", "api/v1/*/emoji", "structuredstyles"},
+	{"", "api/v1/*/emoji_asset_upload_s3", "structuredstyles"},
+	{"", "api/v1/*/emoji_custom_size", "structuredstyles"},
+	{"", "api/v1/*/emoji_permissions", "structuredstyles"},
+
+	{"", "user/*/saved", "history"},
+	{"", "user/*/upvoted", "history"},
+	{"", "user/*/downvoted", "history"},
+	{"", "user/*/hidden", "history"},
+	{"", "user/*/gilded", "history"},
+
+	{http.MethodGet, "api/live", "read"},
+	{"", "api/live/create", "submit"},
+	{"", "api/live/*/update", "submit"},
+	{"", "api/live/*/report", "report"},
+	{"", "api/live", "livemanage"},
+	{http.MethodGet, "live", "read"},
+
+	// most other endpoints that only fetch data require the read scope
+	{http.MethodGet, "", "read"},
+}
+
+var authenticateScopeRegex = regexp.MustCompile(`(?:^|[\s,])scope="([^"]*)"`)
+
+// insufficientScope checks whether the response failed because the client's access token lacks a scope.
+// If so, it returns the scope required by the endpoint, or an empty string if it's unknown.
+func insufficientScope(r *http.Response) (string, bool) {
+	if r.StatusCode != http.StatusForbidden {
+		return "", false
+	}
+
+	header := r.Header.Get(headerWWWAuthenticate)
+	if !strings.Contains(header, "insufficient_scope") {
+		return "", false
+	}
+
+	// the header may specify the required scope, as per RFC 6750
+	if m := authenticateScopeRegex.FindStringSubmatch(header); m != nil {
+		return m[1], true
+	}
+
+	if r.Request == nil {
+		return "", true
+	}
+	return requiredScope(r.Request.Method, r.Request.URL.Path), true
+}
+
+// requiredScope returns the OAuth2 scope required by the endpoint at the path,
+// or an empty string if it's unknown.
+func requiredScope(method, path string) string {
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".json")
+
+	var segments []string
+	if path != "" {
+		segments = strings.Split(path, "/")
+	}
+
+	for _, e := range endpointScopes {
+		if e.method != "" && e.method != method {
+			continue
+		}
+		if matchSegments(e.pattern, segments) {
+			return e.scope
+		}
+	}
+
+	return ""
+}
+
+func matchSegments(pattern string, segments []string) bool {
+	if pattern == "" {
+		return true
+	}
+
+	patternSegments := strings.Split(pattern, "/")
+	if len(segments) < len(patternSegments) {
+		return false
+	}
+
+	for i, p := range patternSegments {
+		if p != "*" && p != segments[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,137 @@
+package reddit
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var expectedScopes = []*Scope{
+	{
+		ID:          "identity",
+		Name:        "My Identity",
+		Description: "Access my reddit username and signup date.",
+	},
+	{
+		ID:          "modposts",
+		Name:        "Moderate Posts",
+		Description: "Approve, remove, mark nsfw, and distinguish content in subreddits I moderate.",
+	},
+	{
+		ID:          "read",
+		Name:        "Read Content",
+		Description: "Access posts and comments through my account.",
+	},
+}
+
+func TestScopesService_List(t *testing.T) {
+	client, mux := setup(t)
+
+	blob, err := readFileContents("../testdata/scopes/list.json")
+	require.NoError(t, err)
+
+	mux.HandleFunc("/api/v1/scopes", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		fmt.Fprint(w, blob)
+	})
+
+	scopes, _, err := client.Scopes.List(ctx)
+	require.NoError(t, err)
+	require.Equal(t, expectedScopes, scopes)
+}
+
+func TestClient_GrantedScopes(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/api/v1/access_token2", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add(headerContentType, mediaTypeJSON)
+		fmt.Fprint(w, `{
+			"access_token": "token1",
+			"token_type": "bearer",
+			"expires_in": 3600,
+			"scope": "identity read,submit"
+		}`)
+	})
+
+	mux.HandleFunc("/api/v1/test", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+	})
+
+	client, err := NewClient(
+		Credentials{"id1", "secret1", "user1", "password1"},
+		WithBaseURL(client.BaseURL.String()),
+		WithTokenURL(client.BaseURL.String()+"/api/v1/access_token2"),
+	)
+	require.NoError(t, err)
+	require.Nil(t, client.GrantedScopes())
+
+	req, err := client.NewRequest(http.MethodGet, "api/v1/test", nil)
+	require.NoError(t, err)
+
+	_, err = client.Do(ctx, req, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"identity", "read", "submit"}, client.GrantedScopes())
+}
+
+func TestClient_InsufficientScopeError(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/api/remove", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		w.Header().Set(headerWWWAuthenticate, `Bearer realm="reddit", error="insufficient_scope"`)
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"message": "Forbidden", "error": 403}`)
+	})
+
+	mux.HandleFunc("/api/v1/test", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerWWWAuthenticate, `Bearer realm="reddit", error="insufficient_scope", scope="custom"`)
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	_, err := client.Moderation.Remove(ctx, "t3_test")
+	require.IsType(t, &InsufficientScopeError{}, err)
+	require.Equal(t, "modposts", err.(*InsufficientScopeError).Scope)
+	require.EqualError(t, err, fmt.Sprintf(`POST %s/api/remove: 403 insufficient scope: the "modposts" scope is required (granted scopes: *)`, client.BaseURL))
+
+	req, err := client.NewRequest(http.MethodGet, "api/v1/test", nil)
+	require.NoError(t, err)
+
+	_, err = client.Do(ctx, req, nil)
+	require.IsType(t, &InsufficientScopeError{}, err)
+	require.Equal(t, "custom", err.(*InsufficientScopeError).Scope)
+}
+
+func TestRequiredScope(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		scope  string
+	}{
+		{http.MethodGet, "/api/v1/me", "identity"},
+		{http.MethodGet, "/api/v1/me/prefs", "identity"},
+		{http.MethodPatch, "/api/v1/me/prefs", "account"},
+		{http.MethodGet, "/api/v1/me/karma", "mysubreddits"},
+		{http.MethodPost, "/api/submit", "submit"},
+		{http.MethodPost, "/api/del", "edit"},
+		{http.MethodPost, "/api/del_msg", "privatemessages"},
+		{http.MethodGet, "/message/inbox", "privatemessages"},
+		{http.MethodGet, "/r/golang/about/log", "modlog"},
+		{http.MethodGet, "/r/golang/about/modqueue", "modposts"},
+		{http.MethodGet, "/r/golang/about/moderators", "read"},
+		{http.MethodPost, "/r/golang/api/flairtemplate_v2", "modflair"},
+		{http.MethodPost, "/r/golang/api/wiki/edit", "wikiedit"},
+		{http.MethodGet, "/r/golang/wiki/index", "wikiread"},
+		{http.MethodPost, "/api/v1/golang/emoji.json", "structuredstyles"},
+		{http.MethodGet, "/api/v1/golang/emojis/all", "read"},
+		{http.MethodGet, "/user/test/saved", "history"},
+		{http.MethodGet, "/user/test/overview", "read"},
+		{http.MethodGet, "/r/golang/new", "read"},
+		{http.MethodPost, "/api/unknown", ""},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.scope, requiredScope(test.method, test.path), "%s %s", test.method, test.path)
+	}
+}
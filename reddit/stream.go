@@ -2,7 +2,11 @@ package reddit
 
 import (
 	"context"
-	"sync"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -19,80 +23,503 @@ type StreamService struct {
 // Because of the 100 post limit imposed by Reddit when fetching posts, some high-traffic
 // streams might drop submissions between API requests, such as when streaming r/all.
 func (s *StreamService) Posts(subreddit string, opts ...StreamOpt) (<-chan *Post, <-chan error, func()) {
-	streamConfig := &streamConfig{
-		Interval:       defaultStreamInterval,
-		DiscardInitial: false,
-		MaxRequests:    0,
-	}
-	for _, opt := range opts {
-		opt(streamConfig)
-	}
-
-	ticker := time.NewTicker(streamConfig.Interval)
+	stream := newStream(opts)
 	postsCh := make(chan *Post)
-	errsCh := make(chan error)
-
-	var once sync.Once
-	stop := func() {
-		once.Do(func() {
-			ticker.Stop()
-			close(postsCh)
-			close(errsCh)
+
+	go func() {
+		defer close(postsCh)
+
+		// originally used the "before" parameter, but if that post gets deleted, subsequent requests
+		// would just return empty listings; easier to just keep track of the post ids encountered
+		var posts []*Post
+		stream.run(func() ([]string, error) {
+			var err error
+			posts, err = s.getPosts(subreddit)
+
+			ids := make([]string, len(posts))
+			for i, post := range posts {
+				ids[i] = post.FullID
+			}
+			return ids, err
+		}, func(i int) bool {
+			select {
+			case postsCh <- posts[i]:
+				return true
+			case <-stream.done:
+				return false
+			}
 		})
-	}
+	}()
 
-	// originally used the "before" parameter, but if that post gets deleted, subsequent requests
-	// would just return empty listings; easier to just keep track of all post ids encountered
-	ids := set{}
+	return postsCh, stream.errsCh, stream.stop
+}
+
+func (s *StreamService) getPosts(subreddit string) ([]*Post, error) {
+	posts, _, err := s.client.Subreddit.NewPosts(context.Background(), subreddit, &ListOptions{Limit: 100})
+	return posts, err
+}
+
+// Comments streams comments from the specified subreddit.
+// To stream from multiple, separate the names with a plus (+), e.g. "golang+test".
+// It returns 2 channels and a function:
+//   - a channel into which new comments will be sent
+//   - a channel into which any errors will be sent
+//   - a function that the client can call once to stop the streaming and close the channels
+// Like with Posts, high-traffic streams might drop comments between API requests, since Reddit
+// returns at most 100 of them per request.
+func (s *StreamService) Comments(subreddit string, opts ...StreamOpt) (<-chan *Comment, <-chan error, func()) {
+	stream := newStream(opts)
+	commentsCh := make(chan *Comment)
 
 	go func() {
-		defer stop()
+		defer close(commentsCh)
+
+		var comments []*Comment
+		stream.run(func() ([]string, error) {
+			var err error
+			comments, err = s.getComments(subreddit)
+
+			ids := make([]string, len(comments))
+			for i, comment := range comments {
+				ids[i] = comment.FullID
+			}
+			return ids, err
+		}, func(i int) bool {
+			select {
+			case commentsCh <- comments[i]:
+				return true
+			case <-stream.done:
+				return false
+			}
+		})
+	}()
+
+	return commentsCh, stream.errsCh, stream.stop
+}
+
+func (s *StreamService) getComments(subreddit string) ([]*Comment, error) {
+	path := fmt.Sprintf("r/%s/comments", subreddit)
+	ctx := withOperation(context.Background(), "Stream.Comments")
+	l, _, err := s.client.getListing(ctx, path, &ListOptions{Limit: 100})
+	if err != nil {
+		return nil, err
+	}
+	return l.Comments(), nil
+}
+
+// Inbox streams comments and messages that appear in your inbox.
+// It returns 2 channels and a function, like Posts. Use StreamMarkRead to mark them as read
+// once they've been received.
+func (s *StreamService) Inbox(opts ...StreamOpt) (<-chan *Message, <-chan error, func()) {
+	return s.inbox("Stream.Inbox", "message/inbox", opts)
+}
+
+// Mentions streams comments in which your username is mentioned.
+// It returns 2 channels and a function, like Posts. Use StreamMarkRead to mark them as read
+// once they've been received.
+func (s *StreamService) Mentions(opts ...StreamOpt) (<-chan *Message, <-chan error, func()) {
+	return s.inbox("Stream.Mentions", "message/mentions", opts)
+}
+
+// UnreadMessages streams unread comments and messages that appear in your inbox.
+// It returns 2 channels and a function, like Posts. Use StreamMarkRead to mark them as read
+// once they've been received.
+func (s *StreamService) UnreadMessages(opts ...StreamOpt) (<-chan *Message, <-chan error, func()) {
+	return s.inbox("Stream.UnreadMessages", "message/unread", opts)
+}
 
-		var n int
-		infinite := streamConfig.MaxRequests == 0
+// Modmail streams the messages sent to the moderators of the subreddits you moderate, from the legacy modmail.
+// The conversations of the new modmail aren't streamed. It returns 2 channels and a function, like Posts.
+// Use StreamMarkRead to mark them as read once they've been received.
+func (s *StreamService) Modmail(opts ...StreamOpt) (<-chan *Message, <-chan error, func()) {
+	return s.inbox("Stream.Modmail", "message/moderator", opts)
+}
 
-		for ; ; <-ticker.C {
-			n++
+func (s *StreamService) inbox(operation, path string, opts []StreamOpt) (<-chan *Message, <-chan error, func()) {
+	stream := newStream(opts)
+	ctx := withOperation(context.Background(), operation)
+	messagesCh := make(chan *Message)
 
-			posts, err := s.getPosts(subreddit)
+	go func() {
+		defer close(messagesCh)
+
+		var messages []*Message
+		stream.run(func() ([]string, error) {
+			messages = nil
+			root, _, err := s.client.Message.inbox(ctx, path, &ListOptions{Limit: 100})
 			if err != nil {
-				errsCh <- err
-				if !infinite && n >= streamConfig.MaxRequests {
-					break
-				}
-				continue
+				return nil, err
 			}
 
-			for _, post := range posts {
-				id := post.FullID
-
-				// if this post id is already part of the set, it means that it and the ones
-				// after it in the list have already been streamed, so break out of the loop
-				if ids.Exists(id) {
-					break
+			ids := make([]string, 0, len(root.all))
+			for _, message := range root.all {
+				if stream.config.MarkRead && !message.Unread {
+					continue
 				}
-				ids.Add(id)
+				messages = append(messages, message)
+				ids = append(ids, message.FullID)
+			}
+			return ids, nil
+		}, func(i int) bool {
+			select {
+			case messagesCh <- messages[i]:
+			case <-stream.done:
+				return false
+			}
 
-				if streamConfig.DiscardInitial {
-					streamConfig.DiscardInitial = false
-					break
+			if stream.config.MarkRead {
+				if _, err := s.client.Message.Read(context.Background(), messages[i].FullID); err != nil {
+					return stream.sendErr(err)
 				}
+			}
+			return true
+		})
+	}()
+
+	return messagesCh, stream.errsCh, stream.stop
+}
+
+// ModQueue streams posts and comments requiring moderator reviews from the specified subreddit,
+// such as ones that have been reported or caught in the spam filter.
+// It returns 2 channels and a function, like Posts.
+func (s *StreamService) ModQueue(subreddit string, opts ...StreamOpt) (<-chan *PostOrComment, <-chan error, func()) {
+	return s.moderationListing("Stream.ModQueue", subreddit, "modqueue", opts)
+}
+
+// Reports streams reported posts and comments from the specified subreddit.
+// It returns 2 channels and a function, like Posts.
+func (s *StreamService) Reports(subreddit string, opts ...StreamOpt) (<-chan *PostOrComment, <-chan error, func()) {
+	return s.moderationListing("Stream.Reports", subreddit, "reports", opts)
+}
+
+// Spam streams posts and comments marked as spam from the specified subreddit.
+// It returns 2 channels and a function, like Posts.
+func (s *StreamService) Spam(subreddit string, opts ...StreamOpt) (<-chan *PostOrComment, <-chan error, func()) {
+	return s.moderationListing("Stream.Spam", subreddit, "spam", opts)
+}
+
+// Unmoderated streams posts that have yet to be approved/removed by a mod from the specified subreddit.
+// It returns 2 channels and a function, like Posts.
+func (s *StreamService) Unmoderated(subreddit string, opts ...StreamOpt) (<-chan *PostOrComment, <-chan error, func()) {
+	return s.moderationListing("Stream.Unmoderated", subreddit, "unmoderated", opts)
+}
+
+// Edited streams posts and comments that have been edited from the specified subreddit.
+// It returns 2 channels and a function, like Posts. A post or comment is only streamed
+// the first time it appears, so edits made to it after that aren't streamed.
+func (s *StreamService) Edited(subreddit string, opts ...StreamOpt) (<-chan *PostOrComment, <-chan error, func()) {
+	return s.moderationListing("Stream.Edited", subreddit, "edited", opts)
+}
+
+func (s *StreamService) moderationListing(operation, subreddit, where string, opts []StreamOpt) (<-chan *PostOrComment, <-chan error, func()) {
+	ctx := withOperation(context.Background(), operation)
+	stream := newStream(opts)
+	itemsCh := make(chan *PostOrComment)
+
+	go func() {
+		defer close(itemsCh)
+
+		var items []*PostOrComment
+		stream.run(func() ([]string, error) {
+			var err error
+			items, err = s.getPostsAndComments(ctx, fmt.Sprintf("r/%s/about/%s", subreddit, where), &ListOptions{Limit: 100})
+
+			ids := make([]string, len(items))
+			for i, item := range items {
+				ids[i] = item.FullID()
+			}
+			return ids, err
+		}, func(i int) bool {
+			select {
+			case itemsCh <- items[i]:
+				return true
+			case <-stream.done:
+				return false
+			}
+		})
+	}()
+
+	return itemsCh, stream.errsCh, stream.stop
+}
+
+// getPostsAndComments returns the posts and comments of the listing at the path, in the order of the listing.
+func (s *StreamService) getPostsAndComments(ctx context.Context, path string, opts interface{}) ([]*PostOrComment, error) {
+	path, err := addOptions(path, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.client.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	root := new(struct {
+		Data struct {
+			Children []thing `json:"children"`
+		} `json:"data"`
+	})
+	if _, err = s.client.Do(ctx, req, root); err != nil {
+		return nil, err
+	}
+
+	var items []*PostOrComment
+	for _, t := range root.Data.Children {
+		if post, ok := t.Post(); ok {
+			items = append(items, &PostOrComment{Post: post})
+		} else if comment, ok := t.Comment(); ok {
+			items = append(items, &PostOrComment{Comment: comment})
+		}
+	}
+	return items, nil
+}
+
+// ModLog streams the actions of the moderators of the specified subreddit from its moderation log.
+// It returns 2 channels and a function, like Posts. Use StreamModerators and StreamModActionTypes
+// to only stream the actions of some moderators, or of some types.
+func (s *StreamService) ModLog(subreddit string, opts ...StreamOpt) (<-chan *ModAction, <-chan error, func()) {
+	ctx := withOperation(context.Background(), "Stream.ModLog")
+	stream := newStream(opts)
+	actionsCh := make(chan *ModAction)
+
+	listOpts := &ListModActionOptions{ListOptions: ListOptions{Limit: 100}}
+	// Reddit filters by multiple moderators, but only by a single type
+	listOpts.Moderator = strings.Join(stream.config.Moderators, ",")
+	if len(stream.config.ModActionTypes) == 1 {
+		listOpts.Type = stream.config.ModActionTypes[0]
+	}
 
-				postsCh <- post
+	moderators := set{}
+	for _, name := range stream.config.Moderators {
+		moderators.Add(strings.ToLower(name))
+	}
+	types := set{}
+	for _, typ := range stream.config.ModActionTypes {
+		types.Add(typ)
+	}
+
+	go func() {
+		defer close(actionsCh)
+
+		var actions []*ModAction
+		stream.run(func() ([]string, error) {
+			actions = nil
+			all, _, err := s.client.Moderation.Actions(ctx, subreddit, listOpts)
+			if err != nil {
+				return nil, err
 			}
 
-			if !infinite && n >= streamConfig.MaxRequests {
-				break
+			ids := make([]string, 0, len(all))
+			for _, action := range all {
+				if moderators.Len() > 0 && !moderators.Exists(strings.ToLower(action.Moderator)) {
+					continue
+				}
+				if types.Len() > 0 && !types.Exists(action.Action) {
+					continue
+				}
+				actions = append(actions, action)
+				ids = append(ids, action.ID)
+			}
+			return ids, nil
+		}, func(i int) bool {
+			select {
+			case actionsCh <- actions[i]:
+				return true
+			case <-stream.done:
+				return false
 			}
+		})
+	}()
+
+	return actionsCh, stream.errsCh, stream.stop
+}
+
+// UserStatus is the status of a user's account.
+type UserStatus string
+
+// The statuses reported by StreamService.UserActivity.
+const (
+	UserStatusActive    UserStatus = ""
+	UserStatusSuspended UserStatus = "suspended"
+	UserStatusDeleted   UserStatus = "deleted"
+)
+
+// UserActivity is an event of a user streamed by StreamService.UserActivity: either a new post
+// or comment of theirs, or a change of the status of their account.
+type UserActivity struct {
+	// The username of the user, as given to StreamService.UserActivity.
+	Username string
+
+	// At most one of these is set.
+	Post    *Post
+	Comment *Comment
+
+	// Set if the event is the account becoming suspended or deleted.
+	Status UserStatus
+}
+
+// UserActivity streams the new posts and comments of the users, and reports when their accounts become
+// suspended or deleted. It returns 2 channels and a function, like Posts.
+// The users' overviews (see UserService.OverviewOf) are polled in turns: every interval, the stream polls as many of them as the remaining
+// rate limit of the client allows until it resets, and at least one.
+func (s *StreamService) UserActivity(usernames []string, opts ...StreamOpt) (<-chan *UserActivity, <-chan error, func()) {
+	stream := newStream(opts)
+	activityCh := make(chan *UserActivity)
+
+	discardInitial := stream.config.DiscardInitial
+	polled := set{}
+	statuses := make(map[string]UserStatus)
+
+	// sendStatus reports whether the stream is still running
+	sendStatus := func(username string, status UserStatus) bool {
+		if status == statuses[username] {
+			return true
 		}
+		statuses[username] = status
+		if status == UserStatusActive {
+			return true
+		}
+
+		select {
+		case activityCh <- &UserActivity{Username: username, Status: status}:
+			return true
+		case <-stream.done:
+			return false
+		}
+	}
+
+	go func() {
+		defer close(activityCh)
+
+		var (
+			next     int
+			activity []*UserActivity
+		)
+		stream.run(func() ([]string, error) {
+			activity = nil
+			if len(usernames) == 0 {
+				return nil, nil
+			}
+
+			var ids []string
+			for n := s.userActivityBatchSize(len(usernames), stream.config.Interval); n > 0; n-- {
+				username := usernames[next]
+				next = (next + 1) % len(usernames)
+
+				items, status, err := s.getUserActivity(username)
+				if err != nil {
+					if !stream.sendErr(err) {
+						return nil, nil
+					}
+					continue
+				}
+				if !sendStatus(username, status) {
+					return nil, nil
+				}
+
+				// the first time a user is polled, their existing activity is discarded if requested
+				discard := discardInitial && !polled.Exists(username)
+				polled.Add(username)
+
+				for _, item := range items {
+					if discard {
+						if err := stream.config.SeenStore.Add(context.Background(), item.FullID()); err != nil && !stream.sendErr(err) {
+							return nil, nil
+						}
+						continue
+					}
+					activity = append(activity, &UserActivity{Username: username, Post: item.Post, Comment: item.Comment})
+					ids = append(ids, item.FullID())
+				}
+			}
+			return ids, nil
+		}, func(i int) bool {
+			select {
+			case activityCh <- activity[i]:
+				return true
+			case <-stream.done:
+				return false
+			}
+		})
 	}()
 
-	return postsCh, errsCh, stop
+	return activityCh, stream.errsCh, stream.stop
 }
 
-func (s *StreamService) getPosts(subreddit string) ([]*Post, error) {
-	posts, _, err := s.client.Subreddit.NewPosts(context.Background(), subreddit, &ListOptions{Limit: 100})
-	return posts, err
+// userActivityBatchSize returns the number of users to poll in an interval, spreading the client's
+// remaining requests until its rate limit resets.
+func (s *StreamService) userActivityBatchSize(users int, interval time.Duration) int {
+	rate, err := s.client.getRate(context.Background())
+	if err != nil || rate.Reset.IsZero() {
+		return 1
+	}
+
+	untilReset := time.Until(rate.Reset)
+	if untilReset < interval {
+		untilReset = interval
+	}
+
+	n := int(float64(rate.Remaining) * float64(interval) / float64(untilReset))
+	if n < 1 {
+		return 1
+	}
+	if n > users {
+		return users
+	}
+	return n
+}
+
+// getUserActivity returns the latest posts and comments of the user, newest first,
+// along with the status of their account.
+func (s *StreamService) getUserActivity(username string) ([]*PostOrComment, UserStatus, error) {
+	ctx := withOperation(context.Background(), "Stream.UserActivity")
+
+	posts, comments, _, err := s.client.User.OverviewOf(ctx, username, &ListUserOverviewOptions{
+		ListOptions: ListOptions{Limit: 100},
+		Sort:        "new",
+	})
+	if err == nil {
+		return newestPostsAndComments(posts, comments), UserStatusActive, nil
+	}
+	if !errors.Is(err, ErrNotFound) && !errors.Is(err, ErrForbidden) {
+		return nil, UserStatusActive, err
+	}
+
+	// the overview of suspended and deleted accounts isn't available
+	user, _, userErr := s.client.User.Get(ctx, username)
+	switch {
+	case errors.Is(userErr, ErrNotFound):
+		return nil, UserStatusDeleted, nil
+	case userErr == nil && user.IsSuspended:
+		return nil, UserStatusSuspended, nil
+	}
+	return nil, UserStatusActive, err
+}
+
+// newestPostsAndComments merges the posts and comments, newest first.
+// The ones without a creation time come last.
+func newestPostsAndComments(posts []*Post, comments []*Comment) []*PostOrComment {
+	items := make([]*PostOrComment, 0, len(posts)+len(comments))
+	created := make(map[*PostOrComment]time.Time, len(posts)+len(comments))
+	for _, post := range posts {
+		item := &PostOrComment{Post: post}
+		if post.Created != nil {
+			created[item] = post.Created.Time
+		}
+		items = append(items, item)
+	}
+	for _, comment := range comments {
+		item := &PostOrComment{Comment: comment}
+		if comment.Created != nil {
+			created[item] = comment.Created.Time
+		}
+		items = append(items, item)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return created[items[i]].After(created[items[j]])
+	})
+	return items
 }
 
 type set map[string]struct{}
@@ -3,6 +3,7 @@ package reddit
 import (
 	"fmt"
 	"net/http"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -308,3 +309,550 @@ loop:
 
 	require.Len(t, expectedPostIDs, i)
 }
+
+func TestStreamService_Comments(t *testing.T) {
+	client, mux := setup(t)
+
+	responses := [][]string{
+		{"t1_comment2", "t1_comment1"},
+		{"t1_comment3", "t1_comment2", "t1_comment1"},
+		{"t1_comment5", "t1_comment3", "t1_comment4"},
+	}
+
+	var counter int
+	mux.HandleFunc("/r/golang+test/comments", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "100", r.URL.Query().Get("limit"))
+		defer func() { counter++ }()
+
+		if counter >= len(responses) {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprint(w, thingListing(responses[counter]...))
+	})
+
+	comments, errs, stop := client.Stream.Comments("golang+test", StreamInterval(time.Millisecond*10), StreamMaxRequests(4))
+	defer stop()
+
+	var ids []string
+	var errCount int
+	for comments != nil || errs != nil {
+		select {
+		case comment, ok := <-comments:
+			if !ok {
+				comments = nil
+				continue
+			}
+			ids = append(ids, comment.FullID)
+		case _, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			errCount++
+		}
+	}
+
+	require.Equal(t, []string{"t1_comment2", "t1_comment1", "t1_comment3", "t1_comment5", "t1_comment4"}, ids)
+	require.Equal(t, 1, errCount)
+}
+
+func TestStreamService_Comments_Operation(t *testing.T) {
+	client, mux := setup(t)
+
+	observer := new(testObserver)
+	require.NoError(t, WithObserver(observer)(client))
+
+	mux.HandleFunc("/r/test/comments", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, thingListing("t1_comment1"))
+	})
+
+	comments, _, stop := client.Stream.Comments("test", StreamMaxRequests(1))
+	defer stop()
+	for range comments {
+	}
+
+	require.Equal(t, "Stream.Comments", observer.events[0].(*RequestStartEvent).Operation)
+}
+
+func TestStreamService_Comments_DiscardInitial(t *testing.T) {
+	client, mux := setup(t)
+
+	var counter int
+	mux.HandleFunc("/r/test/comments", func(w http.ResponseWriter, r *http.Request) {
+		defer func() { counter++ }()
+
+		if counter == 0 {
+			fmt.Fprint(w, thingListing("t1_comment2", "t1_comment1"))
+			return
+		}
+		fmt.Fprint(w, thingListing("t1_comment3", "t1_comment2", "t1_comment1"))
+	})
+
+	comments, _, stop := client.Stream.Comments("test", StreamInterval(time.Millisecond*10), StreamDiscardInitial)
+	defer stop()
+
+	comment := <-comments
+	require.Equal(t, "t1_comment3", comment.FullID)
+
+	stop()
+	for range comments {
+	}
+}
+
+func TestStreamService_Inbox(t *testing.T) {
+	client, mux := setup(t)
+
+	var counter int
+	mux.HandleFunc("/message/inbox", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		defer func() { counter++ }()
+
+		if counter == 0 {
+			fmt.Fprint(w, thingListing("t4_message1", "t1_comment1"))
+			return
+		}
+		fmt.Fprint(w, thingListing("t1_comment2", "t4_message1", "t1_comment1"))
+	})
+
+	messages, errs, stop := client.Stream.Inbox(StreamInterval(time.Millisecond*10), StreamMaxRequests(2))
+	defer stop()
+
+	var ids []string
+	for message := range messages {
+		ids = append(ids, message.FullID)
+	}
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, []string{"t4_message1", "t1_comment1", "t1_comment2"}, ids)
+}
+
+func TestStreamService_UnreadMessages_MarkRead(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/message/unread", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		fmt.Fprint(w, `{"kind": "Listing", "data": {"children": [
+			{"kind": "t4", "data": {"name": "t4_message2", "new": true}},
+			{"kind": "t4", "data": {"name": "t4_message1", "new": false}}
+		]}}`)
+	})
+
+	var read []string
+	mux.HandleFunc("/api/read_message", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.NoError(t, r.ParseForm())
+		read = append(read, r.Form.Get("id"))
+	})
+
+	observer := new(testObserver)
+	require.NoError(t, WithObserver(observer)(client))
+
+	messages, errs, stop := client.Stream.UnreadMessages(StreamMarkRead, StreamMaxRequests(1))
+	defer stop()
+
+	message := <-messages
+	require.Equal(t, "t4_message2", message.FullID)
+
+	_, ok := <-messages
+	require.False(t, ok)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, []string{"t4_message2"}, read)
+
+	var operations []string
+	for _, event := range observer.events {
+		if start, ok := event.(*RequestStartEvent); ok {
+			operations = append(operations, start.Operation)
+		}
+	}
+	require.Equal(t, []string{"Stream.UnreadMessages", "Message.Read"}, operations)
+}
+
+func TestStreamService_Mentions(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/message/mentions", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, thingListing("t1_comment1"))
+	})
+
+	messages, _, stop := client.Stream.Mentions(StreamMaxRequests(1))
+	defer stop()
+
+	message := <-messages
+	require.Equal(t, "t1_comment1", message.FullID)
+}
+
+func TestStreamService_Modmail(t *testing.T) {
+	client, mux := setup(t)
+
+	observer := new(testObserver)
+	require.NoError(t, WithObserver(observer)(client))
+
+	mux.HandleFunc("/message/moderator", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "100", r.URL.Query().Get("limit"))
+		fmt.Fprint(w, thingListing("t4_message2", "t4_message1"))
+	})
+
+	messages, errs, stop := client.Stream.Modmail(StreamMaxRequests(1))
+	defer stop()
+
+	var ids []string
+	for message := range messages {
+		ids = append(ids, message.FullID)
+	}
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, []string{"t4_message2", "t4_message1"}, ids)
+	require.Equal(t, "Stream.Modmail", observer.events[0].(*RequestStartEvent).Operation)
+}
+
+func TestStreamService_ModQueue(t *testing.T) {
+	client, mux := setup(t)
+
+	var counter int
+	mux.HandleFunc("/r/test/about/modqueue", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "100", r.URL.Query().Get("limit"))
+		defer func() { counter++ }()
+
+		if counter == 0 {
+			fmt.Fprint(w, thingListing("t1_comment1", "t3_post1"))
+			return
+		}
+		fmt.Fprint(w, thingListing("t3_post2", "t1_comment1"))
+	})
+
+	items, errs, stop := client.Stream.ModQueue("test", StreamInterval(time.Millisecond*10), StreamMaxRequests(2))
+	defer stop()
+
+	var got []*PostOrComment
+	for item := range items {
+		got = append(got, item)
+	}
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	require.Len(t, got, 3)
+	require.Equal(t, "t1_comment1", got[0].Comment.FullID)
+	require.Nil(t, got[0].Post)
+	require.Equal(t, "t3_post1", got[1].Post.FullID)
+	require.Nil(t, got[1].Comment)
+	require.Equal(t, "t3_post2", got[2].FullID())
+}
+
+func TestStreamService_Reports(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/r/test/about/reports", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, thingListing("t3_post1"))
+	})
+
+	items, _, stop := client.Stream.Reports("test", StreamMaxRequests(1))
+	defer stop()
+
+	item := <-items
+	require.Equal(t, "t3_post1", item.FullID())
+}
+
+func TestStreamService_ModQueue_Operation(t *testing.T) {
+	client, mux := setup(t)
+
+	observer := new(testObserver)
+	require.NoError(t, WithObserver(observer)(client))
+
+	mux.HandleFunc("/r/test/about/spam", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, thingListing("t3_post1"))
+	})
+	mux.HandleFunc("/r/test/about/log", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"kind": "Listing", "data": {"children": []}}`)
+	})
+
+	items, _, stop := client.Stream.Spam("test", StreamMaxRequests(1))
+	defer stop()
+	for range items {
+	}
+
+	actions, _, stop := client.Stream.ModLog("test", StreamMaxRequests(1))
+	defer stop()
+	for range actions {
+	}
+
+	require.Len(t, observer.events, 4)
+	require.Equal(t, "Stream.Spam", observer.events[0].(*RequestStartEvent).Operation)
+	require.Equal(t, "Stream.ModLog", observer.events[2].(*RequestStartEvent).Operation)
+}
+
+func TestStreamService_ModLog(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/r/test/about/log", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "mod1,mod2", r.URL.Query().Get("mod"))
+		require.Empty(t, r.URL.Query().Get("type"))
+
+		fmt.Fprint(w, `{"kind": "Listing", "data": {"children": [
+			{"kind": "modaction", "data": {"id": "ModAction_4", "mod": "mod1", "action": "approvelink"}},
+			{"kind": "modaction", "data": {"id": "ModAction_3", "mod": "mod3", "action": "removelink"}},
+			{"kind": "modaction", "data": {"id": "ModAction_2", "mod": "Mod2", "action": "removecomment"}},
+			{"kind": "modaction", "data": {"id": "ModAction_1", "mod": "mod1", "action": "banuser"}}
+		]}}`)
+	})
+
+	actions, errs, stop := client.Stream.ModLog("test",
+		StreamModerators("mod1", "mod2"),
+		StreamModActionTypes("removelink", "removecomment", "approvelink"),
+		StreamMaxRequests(1),
+	)
+	defer stop()
+
+	var ids []string
+	for action := range actions {
+		ids = append(ids, action.ID)
+	}
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, []string{"ModAction_4", "ModAction_2"}, ids)
+}
+
+func TestStreamService_ModLog_Type(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/r/test/about/log", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "removelink", r.URL.Query().Get("type"))
+		fmt.Fprint(w, `{"kind": "Listing", "data": {"children": [
+			{"kind": "modaction", "data": {"id": "ModAction_1", "mod": "mod1", "action": "removelink"}}
+		]}}`)
+	})
+
+	actions, _, stop := client.Stream.ModLog("test", StreamModActionTypes("removelink"), StreamMaxRequests(1))
+	defer stop()
+
+	action := <-actions
+	require.Equal(t, "ModAction_1", action.ID)
+}
+
+func TestStreamService_UserActivity(t *testing.T) {
+	client, mux := setup(t)
+
+	var counter int
+	mux.HandleFunc("/user/user1/overview", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "new", r.URL.Query().Get("sort"))
+		defer func() { counter++ }()
+
+		if counter == 0 {
+			fmt.Fprint(w, `{"kind": "Listing", "data": {"children": [
+				{"kind": "t1", "data": {"name": "t1_comment1", "created_utc": 1600000002}},
+				{"kind": "t3", "data": {"name": "t3_post1", "created_utc": 1600000001}}
+			]}}`)
+			return
+		}
+		fmt.Fprint(w, `{"kind": "Listing", "data": {"children": [
+			{"kind": "t3", "data": {"name": "t3_post2", "created_utc": 1600000003}},
+			{"kind": "t1", "data": {"name": "t1_comment1", "created_utc": 1600000002}},
+			{"kind": "t3", "data": {"name": "t3_post1", "created_utc": 1600000001}}
+		]}}`)
+	})
+	mux.HandleFunc("/user/user2/overview", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/user/user2/about", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/user/user3/overview", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	mux.HandleFunc("/user/user3/about", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"kind": "t2", "data": {"name": "user3", "is_suspended": true}}`)
+	})
+
+	// without a known rate limit, a single user is polled in every interval
+	activity, errs, stop := client.Stream.UserActivity(
+		[]string{"user1", "user2", "user3"},
+		StreamInterval(time.Millisecond*10),
+		StreamMaxRequests(6),
+	)
+	defer stop()
+
+	var got []*UserActivity
+	for a := range activity {
+		got = append(got, a)
+	}
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	require.Len(t, got, 5)
+	require.Equal(t, "user1", got[0].Username)
+	require.Equal(t, "t1_comment1", got[0].Comment.FullID)
+	require.Equal(t, "t3_post1", got[1].Post.FullID)
+	require.Equal(t, &UserActivity{Username: "user2", Status: UserStatusDeleted}, got[2])
+	require.Equal(t, &UserActivity{Username: "user3", Status: UserStatusSuspended}, got[3])
+	require.Equal(t, "t3_post2", got[4].Post.FullID)
+	require.Equal(t, 2, counter)
+}
+
+func TestStreamService_UserActivity_Operation(t *testing.T) {
+	client, mux := setup(t)
+
+	observer := new(testObserver)
+	require.NoError(t, WithObserver(observer)(client))
+
+	mux.HandleFunc("/user/user1/overview", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, thingListing("t1_comment1"))
+	})
+	mux.HandleFunc("/user/user2/overview", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/user/user2/about", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	activity, _, stop := client.Stream.UserActivity(
+		[]string{"user1", "user2"},
+		StreamInterval(time.Millisecond*10),
+		StreamMaxRequests(2),
+	)
+	defer stop()
+	for range activity {
+	}
+
+	// the overviews and the accounts of the users are requested
+	require.Len(t, observer.events, 6)
+	for i := 0; i < len(observer.events); i += 2 {
+		require.Equal(t, "Stream.UserActivity", observer.events[i].(*RequestStartEvent).Operation)
+	}
+}
+
+func TestNewestPostsAndComments(t *testing.T) {
+	posts := []*Post{
+		{FullID: "t3_post1", Created: &Timestamp{time.Unix(1, 0)}},
+		{FullID: "t3_post2"},
+		{FullID: "t3_post3", Created: &Timestamp{time.Unix(3, 0)}},
+	}
+	comments := []*Comment{
+		{FullID: "t1_comment1", Created: &Timestamp{time.Unix(2, 0)}},
+	}
+
+	var ids []string
+	for _, item := range newestPostsAndComments(posts, comments) {
+		ids = append(ids, item.FullID())
+	}
+	require.Equal(t, []string{"t3_post3", "t1_comment1", "t3_post1", "t3_post2"}, ids)
+}
+
+func TestStreamService_UserActivity_DiscardInitial(t *testing.T) {
+	client, mux := setup(t)
+
+	var counter int
+	mux.HandleFunc("/user/user1/overview", func(w http.ResponseWriter, r *http.Request) {
+		defer func() { counter++ }()
+		if counter == 0 {
+			fmt.Fprint(w, thingListing("t1_comment1"))
+			return
+		}
+		fmt.Fprint(w, thingListing("t3_post1", "t1_comment1"))
+	})
+	mux.HandleFunc("/user/user2/overview", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, thingListing("t1_comment2"))
+	})
+
+	activity, _, stop := client.Stream.UserActivity(
+		[]string{"user1", "user2"},
+		StreamInterval(time.Millisecond*10),
+		StreamMaxRequests(3),
+		StreamDiscardInitial,
+	)
+	defer stop()
+
+	var ids []string
+	for a := range activity {
+		ids = append(ids, a.Post.FullID)
+	}
+	require.Equal(t, []string{"t3_post1"}, ids)
+}
+
+func TestStreamService_userActivityBatchSize(t *testing.T) {
+	client, _ := setup(t)
+
+	require.Equal(t, 1, client.Stream.userActivityBatchSize(10, time.Second*5))
+
+	client.rate = Rate{Remaining: 600, Reset: time.Now().Add(time.Minute * 10)}
+	require.Equal(t, 5, client.Stream.userActivityBatchSize(10, time.Second*5))
+	require.Equal(t, 3, client.Stream.userActivityBatchSize(3, time.Second*5))
+
+	client.rate = Rate{Remaining: 10, Reset: time.Now().Add(time.Minute * 10)}
+	require.Equal(t, 1, client.Stream.userActivityBatchSize(10, time.Second*5))
+
+	client.rate = Rate{Remaining: 8, Reset: time.Now().Add(-time.Second)}
+	require.Equal(t, 8, client.Stream.userActivityBatchSize(10, time.Second*5))
+}
+
+func TestStreamService_Posts_SeenStore(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/r/test/new", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, thingListing("t3_post3", "t3_post2", "t3_post1"))
+	})
+
+	path := filepath.Join(t.TempDir(), "seen")
+	store, err := NewFileSeenStore(path, 100)
+	require.NoError(t, err)
+	require.NoError(t, store.Add(ctx, "t3_post1"))
+
+	posts, _, stop := client.Stream.Posts("test", StreamMaxRequests(1), StreamSeenStore(store))
+	defer stop()
+
+	post := <-posts
+	require.Equal(t, "t3_post3", post.FullID)
+	stop()
+	for range posts {
+	}
+	require.NoError(t, store.Close())
+
+	// after a restart, the stream resumes with the post it didn't send
+	store, err = NewFileSeenStore(path, 100)
+	require.NoError(t, err)
+	defer store.Close()
+
+	posts, _, stop = client.Stream.Posts("test", StreamMaxRequests(1), StreamSeenStore(store))
+	defer stop()
+
+	var ids []string
+	for post := range posts {
+		ids = append(ids, post.FullID)
+	}
+	require.Equal(t, []string{"t3_post2"}, ids)
+}
+
+func TestStreamService_Posts_SeenStoreWindow(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/r/test/new", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, thingListing("t3_post1"))
+	})
+
+	// the post is still in the listing after the window, so it isn't sent again
+	store := NewMemorySeenStore(0, time.Millisecond*30)
+	posts, _, stop := client.Stream.Posts("test",
+		StreamInterval(time.Millisecond*10),
+		StreamMaxRequests(8),
+		StreamSeenStore(store),
+	)
+	defer stop()
+
+	var ids []string
+	for post := range posts {
+		ids = append(ids, post.FullID)
+	}
+	require.Equal(t, []string{"t3_post1"}, ids)
+}
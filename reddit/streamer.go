@@ -1,6 +1,10 @@
 package reddit
 
-import "time"
+import (
+	"context"
+	"sync"
+	"time"
+)
 
 const defaultStreamInterval = time.Second * 5
 
@@ -8,6 +12,10 @@ type streamConfig struct {
 	Interval       time.Duration
 	DiscardInitial bool
 	MaxRequests    int
+	MarkRead       bool
+	Moderators     []string
+	ModActionTypes []string
+	SeenStore      SeenStore
 }
 
 // StreamOpt is a configuration option to configure a stream.
@@ -38,7 +46,141 @@ func StreamMaxRequests(v int) StreamOpt {
 	}
 }
 
+// StreamMarkRead marks the items of a stream of your inbox (Inbox, Mentions, UnreadMessages and Modmail)
+// as read once they've been received from the stream's channel. Items that are already read
+// aren't streamed, so that they aren't processed again, e.g. after a restart.
+func StreamMarkRead(c *streamConfig) {
+	c.MarkRead = true
+}
+
+// StreamModerators only streams the moderation actions of the moderators, when streaming a
+// subreddit's moderation log (see ModLog).
+func StreamModerators(names ...string) StreamOpt {
+	return func(c *streamConfig) {
+		c.Moderators = append(c.Moderators, names...)
+	}
+}
+
+// StreamModActionTypes only streams the moderation actions of the types (e.g. removelink),
+// when streaming a subreddit's moderation log (see ModLog and ListModActionOptions.Type).
+func StreamModActionTypes(types ...string) StreamOpt {
+	return func(c *streamConfig) {
+		c.ModActionTypes = append(c.ModActionTypes, types...)
+	}
+}
+
+// StreamSeenStore sets the store keeping track of the items a stream has already sent.
+// By default, a stream keeps the full IDs of all the items it has sent in memory, which grows
+// without bound. Use a MemorySeenStore to cap it, or a FileSeenStore to have the stream resume
+// where it left off after a restart. If the store is nil, it will not be set and the default will be used.
+func StreamSeenStore(store SeenStore) StreamOpt {
+	return func(c *streamConfig) {
+		if store != nil {
+			c.SeenStore = store
+		}
+	}
+}
+
 // Streamer streams data to the client.
 // type Streamer interface {
 // 	Stream() (<-chan *rootListing, <-chan error, func())
 // }
+
+// stream runs the loop shared by the streams of StreamService: it fetches things every interval,
+// and sends the ones it hasn't seen yet, until it's stopped or reaches its maximum number of requests.
+type stream struct {
+	config *streamConfig
+	errsCh chan error
+	done   chan struct{}
+	once   sync.Once
+}
+
+func newStream(opts []StreamOpt) *stream {
+	config := &streamConfig{
+		Interval:       defaultStreamInterval,
+		DiscardInitial: false,
+		MaxRequests:    0,
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+	if config.SeenStore == nil {
+		config.SeenStore = NewMemorySeenStore(0, 0)
+	}
+
+	return &stream{
+		config: config,
+		errsCh: make(chan error),
+		done:   make(chan struct{}),
+	}
+}
+
+// stop stops the stream. Its channels are closed once the current request is done.
+func (s *stream) stop() {
+	s.once.Do(func() {
+		close(s.done)
+	})
+}
+
+// sendErr sends the error to the stream's error channel. It reports whether the stream is still running.
+func (s *stream) sendErr(err error) bool {
+	select {
+	case s.errsCh <- err:
+		return true
+	case <-s.done:
+		return false
+	}
+}
+
+// run calls fetch every interval, which returns the full IDs of the things it got, and calls send
+// with the index of each thing that hasn't been seen before. send reports whether the stream is
+// still running. Things are added to the seen store once they've been sent.
+// The error channel is closed once run returns.
+func (s *stream) run(fetch func() ([]string, error), send func(i int) bool) {
+	defer close(s.errsCh)
+
+	ctx := context.Background()
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	infinite := s.config.MaxRequests == 0
+	for n := 1; ; n++ {
+		ids, err := fetch()
+		if err != nil && !s.sendErr(err) {
+			return
+		}
+
+		for i, id := range ids {
+			seen, err := s.config.SeenStore.Seen(ctx, id)
+			if err != nil {
+				if !s.sendErr(err) {
+					return
+				}
+				continue
+			}
+			if seen {
+				continue
+			}
+
+			if !s.config.DiscardInitial && !send(i) {
+				return
+			}
+			if err := s.config.SeenStore.Add(ctx, id); err != nil && !s.sendErr(err) {
+				return
+			}
+		}
+		if err == nil {
+			s.config.DiscardInitial = false
+		}
+
+		if !infinite && n >= s.config.MaxRequests {
+			return
+		}
+
+		select {
+		case <-ticker.C:
+		case <-s.done:
+			return
+		}
+	}
+}
@@ -51,6 +51,56 @@ type Ban struct {
 	Note     string `json:"note,omitempty"`
 }
 
+type relationshipListing struct {
+	relationships []*Relationship
+	anchors
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (l *relationshipListing) UnmarshalJSON(b []byte) error {
+	root := new(struct {
+		Data struct {
+			anchorsJSON
+			Relationships []*Relationship `json:"children"`
+		} `json:"data"`
+	})
+
+	err := json.Unmarshal(b, root)
+	if err != nil {
+		return err
+	}
+
+	l.relationships = root.Data.Relationships
+	l.anchors = root.Data.anchors(len(root.Data.Relationships))
+
+	return nil
+}
+
+type banListing struct {
+	bans []*Ban
+	anchors
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (l *banListing) UnmarshalJSON(b []byte) error {
+	root := new(struct {
+		Data struct {
+			anchorsJSON
+			Bans []*Ban `json:"children"`
+		} `json:"data"`
+	})
+
+	err := json.Unmarshal(b, root)
+	if err != nil {
+		return err
+	}
+
+	l.bans = root.Data.Bans
+	l.anchors = root.Data.anchors(len(root.Data.Bans))
+
+	return nil
+}
+
 // SubredditRule is a rule in the subreddit.
 type SubredditRule struct {
 	// One of: comment, link (i.e. post), or all (i.e. both comment and link).
@@ -443,7 +493,7 @@ func (s *SubredditService) Subscribe(ctx context.Context, subreddits ...string)
 func (s *SubredditService) SubscribeByID(ctx context.Context, ids ...string) (*Response, error) {
 	form := url.Values{}
 	form.Set("action", "sub")
-	form.Set("sr", strings.Join(ids, ","))
+	form.Set("sr", strings.Join(toFullnames(kindSubreddit, ids), ","))
 	return s.handleSubscription(ctx, form)
 }
 
@@ -459,7 +509,7 @@ func (s *SubredditService) Unsubscribe(ctx context.Context, subreddits ...string
 func (s *SubredditService) UnsubscribeByID(ctx context.Context, ids ...string) (*Response, error) {
 	form := url.Values{}
 	form.Set("action", "unsub")
-	form.Set("sr", strings.Join(ids, ","))
+	form.Set("sr", strings.Join(toFullnames(kindSubreddit, ids), ","))
 	return s.handleSubscription(ctx, form)
 }
 
@@ -678,19 +728,13 @@ func (s *SubredditService) Banned(ctx context.Context, subreddit string, opts *L
 		return nil, nil, err
 	}
 
-	root := new(struct {
-		Data struct {
-			Bans  []*Ban `json:"children"`
-			After string `json:"after"`
-		} `json:"data"`
-	})
+	root := new(banListing)
 	resp, err := s.client.Do(ctx, req, root)
 	if err != nil {
 		return nil, resp, err
 	}
 
-	resp.After = root.Data.After
-	return root.Data.Bans, resp, nil
+	return root.bans, resp, nil
 }
 
 // Muted gets muted users from the subreddit.
@@ -707,19 +751,13 @@ func (s *SubredditService) Muted(ctx context.Context, subreddit string, opts *Li
 		return nil, nil, err
 	}
 
-	root := new(struct {
-		Data struct {
-			Relationships []*Relationship `json:"children"`
-			After         string          `json:"after"`
-		} `json:"data"`
-	})
+	root := new(relationshipListing)
 	resp, err := s.client.Do(ctx, req, root)
 	if err != nil {
 		return nil, resp, err
 	}
 
-	resp.After = root.Data.After
-	return root.Data.Relationships, resp, nil
+	return root.relationships, resp, nil
 }
 
 // WikiBanned gets banned users from the subreddit.
@@ -736,19 +774,13 @@ func (s *SubredditService) WikiBanned(ctx context.Context, subreddit string, opt
 		return nil, nil, err
 	}
 
-	root := new(struct {
-		Data struct {
-			Bans  []*Ban `json:"children"`
-			After string `json:"after"`
-		} `json:"data"`
-	})
+	root := new(banListing)
 	resp, err := s.client.Do(ctx, req, root)
 	if err != nil {
 		return nil, resp, err
 	}
 
-	resp.After = root.Data.After
-	return root.Data.Bans, resp, nil
+	return root.bans, resp, nil
 }
 
 // Contributors gets contributors (also known as approved users) from the subreddit.
@@ -765,19 +797,13 @@ func (s *SubredditService) Contributors(ctx context.Context, subreddit string, o
 		return nil, nil, err
 	}
 
-	root := new(struct {
-		Data struct {
-			Relationships []*Relationship `json:"children"`
-			After         string          `json:"after"`
-		} `json:"data"`
-	})
+	root := new(relationshipListing)
 	resp, err := s.client.Do(ctx, req, root)
 	if err != nil {
 		return nil, resp, err
 	}
 
-	resp.After = root.Data.After
-	return root.Data.Relationships, resp, nil
+	return root.relationships, resp, nil
 }
 
 // WikiContributors gets contributors of the wiki from the subreddit.
@@ -794,19 +820,13 @@ func (s *SubredditService) WikiContributors(ctx context.Context, subreddit strin
 		return nil, nil, err
 	}
 
-	root := new(struct {
-		Data struct {
-			Relationships []*Relationship `json:"children"`
-			After         string          `json:"after"`
-		} `json:"data"`
-	})
+	root := new(relationshipListing)
 	resp, err := s.client.Do(ctx, req, root)
 	if err != nil {
 		return nil, resp, err
 	}
 
-	resp.After = root.Data.After
-	return root.Data.Relationships, resp, nil
+	return root.relationships, resp, nil
 }
 
 // Moderators gets the moderators of the subreddit.
@@ -1140,7 +1160,7 @@ func (s *SubredditService) Edit(ctx context.Context, subredditID string, request
 	if err != nil {
 		return nil, err
 	}
-	form.Set("sr", subredditID)
+	form.Set("sr", toFullname(kindSubreddit, subredditID))
 	form.Set("api_type", "json")
 
 	path := "api/site_admin"
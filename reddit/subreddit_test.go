@@ -1028,9 +1028,10 @@ func TestSubredditService_Banned(t *testing.T) {
 		fmt.Fprint(w, blob)
 	})
 
-	bans, _, err := client.Subreddit.Banned(ctx, "test", &ListOptions{After: "testafter", Limit: 10})
+	bans, resp, err := client.Subreddit.Banned(ctx, "test", &ListOptions{After: "testafter", Limit: 10})
 	require.NoError(t, err)
 	require.Equal(t, expectedBans, bans)
+	require.Equal(t, len(expectedBans), resp.Dist)
 }
 
 func TestSubredditService_Muted(t *testing.T) {
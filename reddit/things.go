@@ -31,6 +31,58 @@ const (
 
 type anchor interface {
 	After() string
+	Before() string
+	Dist() int
+	Modhash() string
+}
+
+// anchors are the fields of a listing used to paginate through it.
+// Listing types embed it to implement the anchor interface.
+type anchors struct {
+	after   string
+	before  string
+	dist    int
+	modhash string
+}
+
+func (a *anchors) After() string {
+	return a.after
+}
+
+func (a *anchors) Before() string {
+	return a.before
+}
+
+func (a *anchors) Dist() int {
+	return a.dist
+}
+
+func (a *anchors) Modhash() string {
+	return a.modhash
+}
+
+// anchorsJSON is the JSON representation of the anchors in the data of a listing.
+// Embed it in the struct a listing's data is decoded into.
+type anchorsJSON struct {
+	After   string `json:"after"`
+	Before  string `json:"before"`
+	Dist    *int   `json:"dist"`
+	Modhash string `json:"modhash"`
+}
+
+// anchors returns the anchors of a listing with n children.
+// Reddit omits the dist of some listings, in which case n is used instead.
+func (a *anchorsJSON) anchors(n int) anchors {
+	dist := n
+	if a.Dist != nil {
+		dist = *a.Dist
+	}
+	return anchors{
+		after:   a.After,
+		before:  a.Before,
+		dist:    dist,
+		modhash: a.Modhash,
+	}
 }
 
 // thing is an entity on Reddit.
@@ -42,14 +94,39 @@ type thing struct {
 }
 
 func (t *thing) After() string {
+	if a, ok := t.anchor(); ok {
+		return a.After()
+	}
+	return ""
+}
+
+func (t *thing) Before() string {
+	if a, ok := t.anchor(); ok {
+		return a.Before()
+	}
+	return ""
+}
+
+func (t *thing) Dist() int {
+	if a, ok := t.anchor(); ok {
+		return a.Dist()
+	}
+	return 0
+}
+
+func (t *thing) Modhash() string {
+	if a, ok := t.anchor(); ok {
+		return a.Modhash()
+	}
+	return ""
+}
+
+func (t *thing) anchor() (anchor, bool) {
 	if t == nil {
-		return ""
+		return nil, false
 	}
 	a, ok := t.Data.(anchor)
-	if !ok {
-		return ""
-	}
-	return a.After()
+	return a, ok
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
@@ -227,21 +304,17 @@ func (t *thing) StyleSheet() (v *SubredditStyleSheet, ok bool) {
 }
 
 // listing is a list of things coming from the Reddit API.
-// It also contains the after anchor useful to get the next results via subsequent requests.
+// It also contains the anchors useful to get the next or previous results via subsequent requests.
 type listing struct {
 	things things
-	after  string
-}
-
-func (l *listing) After() string {
-	return l.after
+	anchors
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
 func (l *listing) UnmarshalJSON(b []byte) error {
 	root := new(struct {
-		Things things `json:"children"`
-		After  string `json:"after"`
+		anchorsJSON
+		Things []thing `json:"children"`
 	})
 
 	err := json.Unmarshal(b, root)
@@ -249,8 +322,8 @@ func (l *listing) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
-	l.things = root.Things
-	l.after = root.After
+	l.things.add(root.Things...)
+	l.anchors = root.anchors(len(root.Things))
 
 	return nil
 }
@@ -633,3 +706,18 @@ func (pc *PostAndComments) addMoreToTree(more *More) {
 		reply.addMoreToReplies(more)
 	}
 }
+
+// PostOrComment is either a post or a comment, e.g. an item of a subreddit's moderation queue.
+// Exactly one of its fields is set.
+type PostOrComment struct {
+	Post    *Post
+	Comment *Comment
+}
+
+// FullID returns the full ID of the post or comment.
+func (p *PostOrComment) FullID() string {
+	if p.Post != nil {
+		return p.Post.FullID
+	}
+	return p.Comment.FullID
+}
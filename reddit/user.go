@@ -69,12 +69,12 @@ func (s *UserService) Get(ctx context.Context, username string) (*User, *Respons
 	return user, resp, nil
 }
 
-// GetMultipleByID returns multiple users from their full IDs.
+// GetMultipleByID returns multiple users from their full IDs or ID36s.
 // The response body is a map where the keys are the IDs (if they exist), and the value is the user.
 func (s *UserService) GetMultipleByID(ctx context.Context, ids ...string) (map[string]*UserSummary, *Response, error) {
 	params := struct {
 		IDs []string `url:"ids,omitempty,comma"`
-	}{ids}
+	}{toFullnames(kindUser, ids)}
 
 	path := "api/user_data_by_account_ids"
 	path, err := addOptions(path, params)
@@ -300,12 +300,12 @@ func (s *UserService) Block(ctx context.Context, username string) (*Blocked, *Re
 	return root, resp, nil
 }
 
-// BlockByID blocks a user via their full id.
+// BlockByID blocks a user via their full ID or ID36.
 func (s *UserService) BlockByID(ctx context.Context, id string) (*Blocked, *Response, error) {
 	path := "api/block_user"
 
 	form := url.Values{}
-	form.Set("account_id", id)
+	form.Set("account_id", toFullname(kindUser, id))
 
 	req, err := s.client.NewRequest(http.MethodPost, path, form)
 	if err != nil {
@@ -343,7 +343,7 @@ func (s *UserService) Unblock(ctx context.Context, username string) (*Response,
 	return s.client.Do(ctx, req, nil)
 }
 
-// UnblockByID unblocks a user via their full id.
+// UnblockByID unblocks a user via their full ID or ID36.
 func (s *UserService) UnblockByID(ctx context.Context, id string) (*Response, error) {
 	selfID, resp, err := s.client.id(ctx)
 	if err != nil {
@@ -353,7 +353,7 @@ func (s *UserService) UnblockByID(ctx context.Context, id string) (*Response, er
 	path := "api/unfriend"
 
 	form := url.Values{}
-	form.Set("id", id)
+	form.Set("id", toFullname(kindUser, id))
 	form.Set("type", "enemy")
 	form.Set("container", selfID)
 
@@ -775,7 +775,7 @@ func TestUserService_BlockByID(t *testing.T) {
 		require.Equal(t, http.MethodPost, r.Method)
 
 		form := url.Values{}
-		form.Set("account_id", "abc123")
+		form.Set("account_id", "t2_abc123")
 
 		err := r.ParseForm()
 		require.NoError(t, err)
@@ -820,7 +820,7 @@ func TestUserService_UnblockByID(t *testing.T) {
 		require.Equal(t, http.MethodPost, r.Method)
 
 		form := url.Values{}
-		form.Set("id", "abc123")
+		form.Set("id", "t2_abc123")
 		form.Set("type", "enemy")
 		form.Set("container", client.redditID)
 
@@ -124,14 +124,28 @@ func (s *WikiPageSettings) UnmarshalJSON(b []byte) error {
 }
 
 type wikiPageRevisionListing struct {
-	Data struct {
-		Revisions []*WikiPageRevision `json:"children"`
-		After     string              `json:"after"`
-	} `json:"data"`
+	revisions []*WikiPageRevision
+	anchors
 }
 
-func (l *wikiPageRevisionListing) After() string {
-	return l.Data.After
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (l *wikiPageRevisionListing) UnmarshalJSON(b []byte) error {
+	root := new(struct {
+		Data struct {
+			anchorsJSON
+			Revisions []*WikiPageRevision `json:"children"`
+		} `json:"data"`
+	})
+
+	err := json.Unmarshal(b, root)
+	if err != nil {
+		return err
+	}
+
+	l.revisions = root.Data.Revisions
+	l.anchors = root.Data.anchors(len(root.Data.Revisions))
+
+	return nil
 }
 
 // WikiPageRevision is a revision of a wiki page.
@@ -349,7 +363,7 @@ func (s *WikiService) revisions(ctx context.Context, subreddit, page string, opt
 		return nil, resp, err
 	}
 
-	return root.Data.Revisions, resp, nil
+	return root.revisions, resp, nil
 }
 
 // Revisions gets revisions of all pages in the wiki.
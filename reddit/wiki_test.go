@@ -317,9 +317,10 @@ func TestWikiService_Revisions(t *testing.T) {
 		fmt.Fprint(w, blob)
 	})
 
-	wikiPageRevisions, _, err := client.Wiki.Revisions(ctx, "testsubreddit", nil)
+	wikiPageRevisions, resp, err := client.Wiki.Revisions(ctx, "testsubreddit", nil)
 	require.NoError(t, err)
 	require.Equal(t, expectedWikiPageRevisions, wikiPageRevisions)
+	require.Equal(t, len(expectedWikiPageRevisions), resp.Dist)
 }
 
 func TestWikiService_RevisionsPage(t *testing.T) {